@@ -0,0 +1,264 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package pajek implements reading and writing graphs in the Pajek .net
+// format.
+//
+// A Pajek network holds a *Vertices section listing the vertices, numbered
+// from 1, followed by an *Arcs section for directed graphs or an *Edges
+// section for undirected graphs listing the connections between vertices by
+// number with an optional weight. The 1-based vertex numbers are assigned to
+// nodes in ascending order of node ID, and the node ID is written as the
+// vertex label so that graphs with non-contiguous IDs are preserved.
+//
+// Pajek format: http://mrvar.fdv.uni-lj.si/pajek/
+package pajek // import "gonum.org/v1/gonum/graph/encoding/pajek"
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// WritePajek writes g to w in Pajek .net format. If g is a graph.Directed
+// the connections are written as an *Arcs section, otherwise as an *Edges
+// section. If g is a graph.Weighter the weights are obtained from its
+// Weight method, otherwise they are obtained from the edges' Weight method.
+func WritePajek(w io.Writer, g graph.Graph) error {
+	nodes := g.Nodes()
+	sort.Sort(ordered.ByID(nodes))
+	number := make(map[int]int, len(nodes))
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "*Vertices %d\n", len(nodes))
+	for i, n := range nodes {
+		number[n.ID()] = i + 1
+		fmt.Fprintf(bw, "%d \"%d\"\n", i+1, n.ID())
+	}
+
+	_, directed := g.(graph.Directed)
+	if directed {
+		fmt.Fprintln(bw, "*Arcs")
+	} else {
+		fmt.Fprintln(bw, "*Edges")
+	}
+	weight := weightFunc(g)
+	for _, u := range nodes {
+		to := g.From(u)
+		sort.Sort(ordered.ByID(to))
+		for _, v := range to {
+			if !directed && v.ID() < u.ID() {
+				continue
+			}
+			fmt.Fprintf(bw, "%d %d %s\n", number[u.ID()], number[v.ID()], strconv.FormatFloat(weight(u, v), 'g', -1, 64))
+		}
+	}
+	return bw.Flush()
+}
+
+// weightFunc returns a function returning the weight of the edge from u to
+// v in g. The edge must exist.
+func weightFunc(g graph.Graph) func(u, v graph.Node) float64 {
+	if wg, ok := g.(graph.Weighter); ok {
+		return func(u, v graph.Node) float64 {
+			w, _ := wg.Weight(u, v)
+			return w
+		}
+	}
+	return func(u, v graph.Node) float64 {
+		return g.Edge(u, v).Weight()
+	}
+}
+
+// ReadPajek reads a graph in Pajek .net format from r. A network with an
+// *Arcs section is returned as a *simple.DirectedGraph and one with an
+// *Edges section, or with no connections, as a *simple.UndirectedGraph.
+// The graphs have a self weight of zero and an absent weight of +Inf.
+//
+// The graph holds a node for each of the vertices declared by the *Vertices
+// count, whether or not they are listed, or for each listed vertex if there
+// is no count. Vertex k is given node ID k-1, unless every vertex has an
+// integer label and no two labels are equal, in which case the labels are
+// used as the node IDs, as written by WritePajek. Connections without a
+// weight are given a weight of one. Lines beginning with '%' are comments.
+// ReadPajek returns an error if the network mixes arcs and edges, if a
+// vertex is listed twice or is outside the declared count, if a connection
+// refers to an unknown vertex or is a self loop, or if a line cannot be
+// parsed.
+func ReadPajek(r io.Reader) (graph.Graph, error) {
+	type connection struct {
+		u, v, line int
+		w          float64
+	}
+	var (
+		n       = -1
+		labels  = make(map[int]string)
+		maxNum  int
+		section string
+		kind    string
+		conns   []connection
+	)
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		text := strings.TrimSpace(sc.Text())
+		if text == "" || strings.HasPrefix(text, "%") {
+			continue
+		}
+		if strings.HasPrefix(text, "*") {
+			f := strings.Fields(text)
+			section = strings.ToLower(f[0])
+			switch section {
+			case "*vertices":
+				if len(f) > 1 {
+					count, err := strconv.Atoi(f[1])
+					if err != nil || count < 0 {
+						return nil, fmt.Errorf("pajek: line %d: invalid vertex count %q", line, f[1])
+					}
+					n = count
+				}
+			case "*arcs", "*edges":
+				if kind != "" && kind != section {
+					return nil, errors.New("pajek: mixed arcs and edges")
+				}
+				kind = section
+			default:
+				return nil, fmt.Errorf("pajek: line %d: unknown section %q", line, section)
+			}
+			continue
+		}
+
+		switch section {
+		case "*vertices":
+			num, label, err := parseVertex(text)
+			if err != nil {
+				return nil, fmt.Errorf("pajek: line %d: %v", line, err)
+			}
+			if n >= 0 && num > n {
+				return nil, fmt.Errorf("pajek: line %d: vertex %d outside declared count %d", line, num, n)
+			}
+			if _, dup := labels[num]; dup {
+				return nil, fmt.Errorf("pajek: line %d: duplicate vertex %d", line, num)
+			}
+			labels[num] = label
+			if num > maxNum {
+				maxNum = num
+			}
+		case "*arcs", "*edges":
+			f := strings.Fields(text)
+			if len(f) < 2 {
+				return nil, fmt.Errorf("pajek: line %d: missing vertex", line)
+			}
+			u, err := vertexNumber(f[0])
+			if err != nil {
+				return nil, fmt.Errorf("pajek: line %d: %v", line, err)
+			}
+			v, err := vertexNumber(f[1])
+			if err != nil {
+				return nil, fmt.Errorf("pajek: line %d: %v", line, err)
+			}
+			if u == v {
+				return nil, fmt.Errorf("pajek: line %d: self loop not supported", line)
+			}
+			w := 1.0
+			if len(f) > 2 {
+				w, err = strconv.ParseFloat(f[2], 64)
+				if err != nil {
+					return nil, fmt.Errorf("pajek: line %d: invalid weight %q", line, f[2])
+				}
+			}
+			conns = append(conns, connection{u: u, v: v, line: line, w: w})
+		default:
+			return nil, fmt.Errorf("pajek: line %d: data outside section", line)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if n < 0 {
+		n = maxNum
+	}
+	for _, c := range conns {
+		for _, num := range []int{c.u, c.v} {
+			if num > n {
+				return nil, fmt.Errorf("pajek: line %d: unknown vertex %d", c.line, num)
+			}
+		}
+	}
+
+	ids := vertexIDs(n, labels)
+	var g interface {
+		graph.Graph
+		graph.Builder
+	}
+	if kind == "*arcs" {
+		g = simple.NewDirectedGraph(0, math.Inf(1))
+	} else {
+		g = simple.NewUndirectedGraph(0, math.Inf(1))
+	}
+	for _, id := range ids {
+		g.AddNode(simple.Node(id))
+	}
+	for _, c := range conns {
+		g.SetEdge(simple.Edge{F: simple.Node(ids[c.u-1]), T: simple.Node(ids[c.v-1]), W: c.w})
+	}
+	return g, nil
+}
+
+// vertexIDs returns the node IDs of the n vertices of a network with the
+// given vertex labels keyed by vertex number. The labels are used as the IDs
+// if all n vertices have a unique integer label, otherwise vertex k is given
+// ID k-1.
+func vertexIDs(n int, labels map[int]string) []int {
+	ids := make([]int, n)
+	used := make(map[int]bool, n)
+	for k := range ids {
+		id, err := strconv.Atoi(labels[k+1])
+		if err != nil || used[id] {
+			for k := range ids {
+				ids[k] = k
+			}
+			return ids
+		}
+		ids[k] = id
+		used[id] = true
+	}
+	return ids
+}
+
+// parseVertex parses a vertex line, returning the vertex number and its
+// label. The label is empty if the vertex has none.
+func parseVertex(text string) (num int, label string, err error) {
+	f := strings.Fields(text)
+	num, err = vertexNumber(f[0])
+	if err != nil {
+		return 0, "", err
+	}
+	label = strings.TrimSpace(text[len(f[0]):])
+	if strings.HasPrefix(label, `"`) {
+		if end := strings.Index(label[1:], `"`); end >= 0 {
+			label = label[1 : end+1]
+		}
+	} else if fields := strings.Fields(label); len(fields) != 0 {
+		label = fields[0]
+	}
+	return num, label, nil
+}
+
+// vertexNumber parses a 1-based vertex number.
+func vertexNumber(field string) (int, error) {
+	num, err := strconv.Atoi(field)
+	if err != nil || num < 1 {
+		return 0, fmt.Errorf("invalid vertex number %q", field)
+	}
+	return num, nil
+}
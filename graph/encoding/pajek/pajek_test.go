@@ -0,0 +1,161 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pajek
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// dummyGraph returns the small directed test graph used by the simple
+// package tests.
+func dummyGraph() *simple.DirectedGraph {
+	g := simple.NewDirectedGraph(0, math.Inf(1))
+	for _, e := range []struct{ from, to int }{
+		{2, 1},
+		{1, 0},
+		{2, 0},
+		{0, 2},
+	} {
+		g.SetEdge(simple.Edge{F: simple.Node(e.from), T: simple.Node(e.to), W: 1})
+	}
+	return g
+}
+
+func TestRoundTrip(t *testing.T) {
+	weighted := dummyGraph()
+	weighted.SetEdge(simple.Edge{F: simple.Node(5), T: simple.Node(2), W: 0.25})
+	weighted.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(5), W: -3})
+
+	undirected := simple.NewUndirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.Edge{
+		{F: simple.Node(0), T: simple.Node(1), W: 2},
+		{F: simple.Node(1), T: simple.Node(3), W: 1.5},
+		{F: simple.Node(4), T: simple.Node(3), W: 7},
+		{F: simple.Node(2), T: simple.Node(0), W: 1},
+	} {
+		undirected.SetEdge(e)
+	}
+	undirected.RemoveNode(simple.Node(2))
+	undirected.AddNode(simple.Node(9))
+
+	for _, g := range []graph.Graph{dummyGraph(), weighted, undirected} {
+		var buf bytes.Buffer
+		if err := WritePajek(&buf, g); err != nil {
+			t.Fatalf("unexpected error writing graph: %v", err)
+		}
+		got, err := ReadPajek(&buf)
+		if err != nil {
+			t.Fatalf("unexpected error reading graph: %v", err)
+		}
+		_, wantDirected := g.(graph.Directed)
+		if _, gotDirected := got.(graph.Directed); gotDirected != wantDirected {
+			t.Errorf("unexpected directedness: got:%t want:%t", gotDirected, wantDirected)
+		}
+		if gotIDs, wantIDs := nodeIDs(got.Nodes()), nodeIDs(g.Nodes()); !reflect.DeepEqual(gotIDs, wantIDs) {
+			t.Errorf("unexpected node IDs: got:%v want:%v", gotIDs, wantIDs)
+		}
+		for _, u := range g.Nodes() {
+			if gotTo, wantTo := nodeIDs(got.From(u)), nodeIDs(g.From(u)); !reflect.DeepEqual(gotTo, wantTo) {
+				t.Errorf("unexpected neighbors of %d: got:%v want:%v", u.ID(), gotTo, wantTo)
+			}
+			for _, v := range g.From(u) {
+				gw, _ := got.(graph.Weighter).Weight(u, v)
+				ww, _ := g.(graph.Weighter).Weight(u, v)
+				if gw != ww {
+					t.Errorf("unexpected weight for %d--%d: got:%v want:%v", u.ID(), v.ID(), gw, ww)
+				}
+			}
+		}
+	}
+}
+
+func TestReadPajek(t *testing.T) {
+	const net = `% A network with unlabeled vertices.
+*Vertices 3
+1 "a" 0.1 0.2
+2
+3 "b"
+*Edges
+1 2 4
+2 3
+`
+	g, err := ReadPajek(strings.NewReader(net))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := nodeIDs(g.Nodes()), []int{0, 1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected node IDs: got:%v want:%v", got, want)
+	}
+	w := g.(graph.Weighter)
+	if got, _ := w.Weight(simple.Node(0), simple.Node(1)); got != 4 {
+		t.Errorf("unexpected weight: got:%v want:4", got)
+	}
+	if got, _ := w.Weight(simple.Node(2), simple.Node(1)); got != 1 {
+		t.Errorf("unexpected default weight: got:%v want:1", got)
+	}
+
+	for _, test := range []struct {
+		net   string
+		edges [][2]int
+		want  []int
+	}{
+		// Labels that are not all unique integers are not used as IDs.
+		{net: "*Vertices 3\n1 \"2\"\n2\n3\n", want: []int{0, 1, 2}},
+		{net: "*Vertices 3\n1 \"5\"\n2 \"5\"\n3 \"7\"\n*Arcs\n1 2\n", edges: [][2]int{{0, 1}}, want: []int{0, 1, 2}},
+		{net: "*Vertices 3\n1 \"2\"\n2 \"0\"\n3 \"9\"\n*Arcs\n1 3\n", edges: [][2]int{{2, 9}}, want: []int{0, 2, 9}},
+
+		// Declared vertices need not be listed.
+		{net: "*Vertices 3\n*Arcs\n1 3\n", edges: [][2]int{{0, 2}}, want: []int{0, 1, 2}},
+	} {
+		g, err := ReadPajek(strings.NewReader(test.net))
+		if err != nil {
+			t.Errorf("unexpected error for %q: %v", test.net, err)
+			continue
+		}
+		if got := nodeIDs(g.Nodes()); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected node IDs for %q: got:%v want:%v", test.net, got, test.want)
+		}
+		for _, e := range test.edges {
+			if !g.HasEdgeBetween(simple.Node(e[0]), simple.Node(e[1])) {
+				t.Errorf("missing edge %d--%d for %q", e[0], e[1], test.net)
+			}
+		}
+	}
+
+	for _, test := range []struct {
+		net  string
+		want string
+	}{
+		{net: "*Vertices 2\n1\n2\n*Arcs\n1 2\n*Edges\n2 1\n", want: "pajek: mixed arcs and edges"},
+		{net: "*Vertices 2\n1\n1\n", want: "pajek: line 3: duplicate vertex 1"},
+		{net: "*Vertices 2\n3\n", want: "pajek: line 2: vertex 3 outside declared count 2"},
+		{net: "*Vertices 1\n1\n*Arcs\n1 2\n", want: "pajek: line 4: unknown vertex 2"},
+		{net: "*Vertices 2\n1\n2\n*Arcs\n1 2 x\n", want: `pajek: line 5: invalid weight "x"`},
+	} {
+		_, err := ReadPajek(strings.NewReader(test.net))
+		if err == nil || err.Error() != test.want {
+			t.Errorf("unexpected error for %q: got:%v want:%s", test.net, err, test.want)
+		}
+	}
+}
+
+// nodeIDs returns the sorted IDs of nodes.
+func nodeIDs(nodes []graph.Node) []int {
+	sort.Sort(ordered.ByID(nodes))
+	ids := make([]int, len(nodes))
+	for i, n := range nodes {
+		ids[i] = n.ID()
+	}
+	return ids
+}
@@ -0,0 +1,11 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package flow provides network flow functions.
+package flow // import "gonum.org/v1/gonum/graph/flow"
+
+// Edge identifies a directed edge by the IDs of its terminal nodes.
+type Edge struct {
+	From, To int
+}
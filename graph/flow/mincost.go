@@ -0,0 +1,191 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"errors"
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// tol is the tolerance used to decide whether supplies balance and whether
+// residual capacities are exhausted.
+const tol = 1e-12
+
+// MinCostFlow returns a minimum cost flow in g satisfying the given node
+// supplies, keyed by node ID, with positive values for supply and negative
+// values for demand. Nodes not present in supply have zero net flow. The
+// capacity and cost of each edge of g are given by the capacity and cost
+// functions called with the IDs of the edge's terminal nodes. The flow on
+// each edge carrying non-zero flow and the total cost of the flow are
+// returned. If g has an Absent method, edges of g carrying the absent weight
+// it returns are treated as absent, where a NaN absent weight matches NaN
+// edge weights.
+//
+// MinCostFlow uses the successive shortest path algorithm with Bellman-Ford
+// searches of the residual graph. MinCostFlow returns an error if the
+// supplies do not sum to zero, if a supply node is not in g, if the
+// supplies cannot be satisfied given the edge capacities, or if g has a
+// negative cost cycle.
+func MinCostFlow(g graph.Directed, supply map[int]float64, capacity, cost func(u, v int) float64) (map[Edge]float64, float64, error) {
+	nodes := g.Nodes()
+	indexOf := make(map[int]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+
+	// Add a super source and super sink to the residual network.
+	var r residual
+	r.init(len(nodes) + 2)
+	src, dst := len(nodes), len(nodes)+1
+
+	var total, balance float64
+	for id, s := range supply {
+		i, ok := indexOf[id]
+		if !ok {
+			return nil, 0, errors.New("flow: supply node not in graph")
+		}
+		switch {
+		case s > 0:
+			r.addArc(src, i, s, 0)
+			total += s
+		case s < 0:
+			r.addArc(i, dst, -s, 0)
+		}
+		balance += s
+	}
+	if math.Abs(balance) > tol*math.Max(1, total) {
+		return nil, 0, errors.New("flow: unbalanced supply")
+	}
+
+	type arcRef struct {
+		edge Edge
+		node int
+		arc  int
+	}
+	isAbsent := func(float64) bool { return false }
+	if a, ok := g.(absenter); ok {
+		absent := a.Absent()
+		isAbsent = func(w float64) bool {
+			return w == absent || (math.IsNaN(w) && math.IsNaN(absent))
+		}
+	}
+	var refs []arcRef
+	for i, u := range nodes {
+		for _, v := range g.From(u) {
+			if isAbsent(g.Edge(u, v).Weight()) {
+				continue
+			}
+			e := Edge{From: u.ID(), To: v.ID()}
+			refs = append(refs, arcRef{edge: e, node: i, arc: len(r.arcs[i])})
+			r.addArc(i, indexOf[v.ID()], capacity(e.From, e.To), cost(e.From, e.To))
+		}
+	}
+
+	var sent, totalCost float64
+	for sent < total-tol*math.Max(1, total) {
+		dist, prev, err := r.shortest(src)
+		if err != nil {
+			return nil, 0, err
+		}
+		if math.IsInf(dist[dst], 1) {
+			return nil, 0, errors.New("flow: infeasible supply")
+		}
+
+		// Find the bottleneck capacity and augment along the path.
+		f := math.Inf(1)
+		for v := dst; v != src; {
+			p := prev[v]
+			f = math.Min(f, r.arcs[p.node][p.arc].cap)
+			v = p.node
+		}
+		for v := dst; v != src; {
+			p := prev[v]
+			a := &r.arcs[p.node][p.arc]
+			a.cap -= f
+			r.arcs[a.to][a.rev].cap += f
+			v = p.node
+		}
+		sent += f
+		totalCost += f * dist[dst]
+	}
+
+	flow := make(map[Edge]float64)
+	for _, ref := range refs {
+		a := r.arcs[ref.node][ref.arc]
+		if f := r.arcs[a.to][a.rev].cap; f > tol {
+			flow[ref.edge] = f
+		}
+	}
+	return flow, totalCost, nil
+}
+
+// absenter is a graph that reports the weight used to indicate an absent edge.
+type absenter interface {
+	Absent() float64
+}
+
+// residual is a residual network held as adjacency lists of arcs.
+type residual struct {
+	arcs [][]arc
+}
+
+// arc is a residual network arc. The reverse arc is arcs[to][rev].
+type arc struct {
+	to, rev   int
+	cap, cost float64
+}
+
+// pred is the arc used to reach a node in a shortest path search.
+type pred struct {
+	node, arc int
+}
+
+func (r *residual) init(n int) {
+	r.arcs = make([][]arc, n)
+}
+
+// addArc adds an arc from u to v with the given capacity and cost and its
+// zero-capacity reverse arc.
+func (r *residual) addArc(u, v int, cap, cost float64) {
+	r.arcs[u] = append(r.arcs[u], arc{to: v, rev: len(r.arcs[v]), cap: cap, cost: cost})
+	r.arcs[v] = append(r.arcs[v], arc{to: u, rev: len(r.arcs[u]) - 1, cap: 0, cost: -cost})
+}
+
+// shortest returns the cheapest distances from s through arcs with
+// remaining capacity and the arcs used to reach each node. It returns an
+// error if a negative cost cycle is reachable.
+func (r *residual) shortest(s int) (dist []float64, prev []pred, err error) {
+	n := len(r.arcs)
+	dist = make([]float64, n)
+	for i := range dist {
+		dist[i] = math.Inf(1)
+	}
+	dist[s] = 0
+	prev = make([]pred, n)
+	for i := 0; i < n; i++ {
+		changed := false
+		for u, arcs := range r.arcs {
+			if math.IsInf(dist[u], 1) {
+				continue
+			}
+			for j, a := range arcs {
+				if a.cap <= tol {
+					continue
+				}
+				if d := dist[u] + a.cost; d < dist[a.to] {
+					dist[a.to] = d
+					prev[a.to] = pred{node: u, arc: j}
+					changed = true
+				}
+			}
+		}
+		if !changed {
+			return dist, prev, nil
+		}
+	}
+	return nil, nil, errors.New("flow: negative cost cycle")
+}
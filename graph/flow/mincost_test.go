@@ -0,0 +1,119 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestMinCostFlow(t *testing.T) {
+	// Sources 0 and 1 supply 20 and 30 units to sinks 2 and 3
+	// which each demand 25 units.
+	const (
+		s1 = iota
+		s2
+		t1
+		t2
+	)
+	costs := map[Edge]float64{
+		{From: s1, To: t1}: 2,
+		{From: s1, To: t2}: 4,
+		{From: s2, To: t1}: 3,
+		{From: s2, To: t2}: 1,
+	}
+	g := simple.NewDirectedGraph(0, math.Inf(1))
+	for e := range costs {
+		g.SetEdge(simple.Edge{F: simple.Node(e.From), T: simple.Node(e.To), W: costs[e]})
+	}
+	supply := map[int]float64{s1: 20, s2: 30, t1: -25, t2: -25}
+	cost := func(u, v int) float64 { return costs[Edge{From: u, To: v}] }
+
+	for _, test := range []struct {
+		name     string
+		capacity func(u, v int) float64
+		wantFlow map[Edge]float64
+		wantCost float64
+	}{
+		{
+			name:     "uncapacitated",
+			capacity: func(u, v int) float64 { return 100 },
+			wantFlow: map[Edge]float64{
+				{From: s1, To: t1}: 20,
+				{From: s2, To: t1}: 5,
+				{From: s2, To: t2}: 25,
+			},
+			wantCost: 80,
+		},
+		{
+			name: "capacitated",
+			capacity: func(u, v int) float64 {
+				if u == s2 && v == t2 {
+					return 20
+				}
+				return 100
+			},
+			wantFlow: map[Edge]float64{
+				{From: s1, To: t1}: 15,
+				{From: s1, To: t2}: 5,
+				{From: s2, To: t1}: 10,
+				{From: s2, To: t2}: 20,
+			},
+			wantCost: 100,
+		},
+	} {
+		flow, total, err := MinCostFlow(g, supply, test.capacity, cost)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %v", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(flow, test.wantFlow) {
+			t.Errorf("unexpected flow for %s: got:%v want:%v", test.name, flow, test.wantFlow)
+		}
+		if total != test.wantCost {
+			t.Errorf("unexpected cost for %s: got:%v want:%v", test.name, total, test.wantCost)
+		}
+	}
+
+	small := func(u, v int) float64 { return 10 }
+	if _, _, err := MinCostFlow(g, supply, small, cost); err == nil {
+		t.Error("expected error for infeasible supply")
+	}
+	unbalanced := map[int]float64{s1: 20, t1: -10}
+	if _, _, err := MinCostFlow(g, unbalanced, small, cost); err == nil {
+		t.Error("expected error for unbalanced supply")
+	}
+
+	// The s2->t2 edge carries the finite absent weight of the
+	// graph, so all of the supply of s2 must go to t1.
+	const absent = 1e9
+	g = simple.NewDirectedGraph(0, absent)
+	for e := range costs {
+		w := costs[e]
+		if e == (Edge{From: s2, To: t2}) {
+			w = absent
+		}
+		g.SetEdge(simple.Edge{F: simple.Node(e.From), T: simple.Node(e.To), W: w})
+	}
+	supply = map[int]float64{s1: 20, s2: 25, t1: -25, t2: -20}
+	uncapacitated := func(u, v int) float64 { return 100 }
+	flow, total, err := MinCostFlow(g, supply, uncapacitated, cost)
+	if err != nil {
+		t.Fatalf("unexpected error with absent edge: %v", err)
+	}
+	wantFlow := map[Edge]float64{
+		{From: s1, To: t2}: 20,
+		{From: s2, To: t1}: 25,
+	}
+	if !reflect.DeepEqual(flow, wantFlow) {
+		t.Errorf("unexpected flow with absent edge: got:%v want:%v", flow, wantFlow)
+	}
+	if total != 155 {
+		t.Errorf("unexpected cost with absent edge: got:%v want:155", total)
+	}
+}
@@ -153,6 +153,31 @@ func TestAStar(t *testing.T) {
 	}
 }
 
+func TestAStarEuclideanHeuristic(t *testing.T) {
+	g := internal.NewGrid(20, 20, true)
+	g.AllowDiagonal = true
+	euclidean := func(u, v graph.Node) float64 {
+		ux, uy := g.XY(u)
+		vx, vy := g.XY(v)
+		return math.Hypot(ux-vx, uy-vy)
+	}
+
+	s, tgt := g.NodeAt(2, 3), g.NodeAt(17, 11)
+	pt, expanded := AStar(s, tgt, g, euclidean)
+	nullPt, nullExpanded := AStar(s, tgt, g, NullHeuristic)
+
+	_, cost := pt.To(tgt)
+	if want := DijkstraFrom(s, g).WeightTo(tgt); math.Abs(cost-want) > 1e-12 {
+		t.Errorf("unexpected cost with Euclidean heuristic: got:%v want:%v", cost, want)
+	}
+	if _, nullCost := nullPt.To(tgt); math.Abs(nullCost-cost) > 1e-12 {
+		t.Errorf("unexpected cost with null heuristic: got:%v want:%v", nullCost, cost)
+	}
+	if expanded >= nullExpanded {
+		t.Errorf("Euclidean heuristic did not reduce expansions: got:%d null heuristic:%d", expanded, nullExpanded)
+	}
+}
+
 func TestExhaustiveAStar(t *testing.T) {
 	g := simple.NewUndirectedGraph(0, math.Inf(1))
 	nodes := []locatedNode{
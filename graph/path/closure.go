@@ -0,0 +1,60 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/mat"
+)
+
+// WeightedTransitiveClosure returns the weighted transitive closure of g as
+// a matrix of the minimum path costs between all pairs of nodes in g, with
+// +Inf for pairs where the second node is not reachable from the first and
+// zero on the diagonal. Rows and columns of the matrix are indexed by the
+// position of the node ID in ids, which is sorted in ascending order. The
+// costs are computed using FloydWarshall. If the graph does not implement
+// graph.Weighter, UniformCost is used.
+//
+// WeightedTransitiveClosure will panic if g has a negative cycle.
+func WeightedTransitiveClosure(g graph.Directed) (closure *mat.Dense, ids []int) {
+	return DistanceMatrix(g)
+}
+
+// DistanceMatrix returns the matrix of shortest path distances between all
+// pairs of nodes in g, treating edge weights as dissimilarities, with +Inf
+// for pairs where the second node is not reachable from the first and zero
+// on the diagonal. For undirected graphs the matrix is symmetric. Rows and
+// columns of the matrix are indexed by the position of the node ID in ids,
+// which is sorted in ascending order. The distances are computed using
+// FloydWarshall. If the graph does not implement graph.Weighter, UniformCost
+// is used.
+//
+// DistanceMatrix will panic if g has a negative cycle.
+func DistanceMatrix(g graph.Graph) (dist *mat.Dense, ids []int) {
+	paths, ok := FloydWarshall(g)
+	if !ok {
+		panic("path: negative cycle")
+	}
+
+	nodes := g.Nodes()
+	sort.Sort(ordered.ByID(nodes))
+	ids = make([]int, len(nodes))
+	for i, n := range nodes {
+		ids[i] = n.ID()
+	}
+	if len(nodes) == 0 {
+		return &mat.Dense{}, ids
+	}
+	dist = mat.NewDense(len(nodes), len(nodes), nil)
+	for i, u := range nodes {
+		for j, v := range nodes {
+			dist.Set(i, j, paths.Weight(u, v))
+		}
+	}
+	return dist, ids
+}
@@ -0,0 +1,107 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestWeightedTransitiveClosure(t *testing.T) {
+	g := simple.NewDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.Edge{
+		{F: simple.Node(4), T: simple.Node(1), W: 2},
+		{F: simple.Node(1), T: simple.Node(2), W: 3},
+		{F: simple.Node(4), T: simple.Node(2), W: 7},
+		{F: simple.Node(2), T: simple.Node(6), W: 1},
+		{F: simple.Node(6), T: simple.Node(1), W: 0.5},
+	} {
+		g.SetEdge(e)
+	}
+	g.AddNode(simple.Node(0))
+
+	closure, ids := WeightedTransitiveClosure(g)
+	if want := []int{0, 1, 2, 4, 6}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("unexpected ids: got:%v want:%v", ids, want)
+	}
+	for i, u := range ids {
+		pt := DijkstraFrom(simple.Node(u), g)
+		for j, v := range ids {
+			got := closure.At(i, j)
+			want := pt.WeightTo(simple.Node(v))
+			if u == v {
+				want = 0
+			}
+			if got != want {
+				t.Errorf("unexpected closure cost from %d to %d: got:%v want:%v", u, v, got, want)
+			}
+		}
+	}
+	for _, test := range []struct {
+		from, to int
+		want     float64
+	}{
+		{from: 4, to: 6, want: 6},
+		{from: 6, to: 2, want: 3.5},
+		{from: 1, to: 4, want: math.Inf(1)},
+		{from: 0, to: 1, want: math.Inf(1)},
+	} {
+		i, j := indexIn(ids, test.from), indexIn(ids, test.to)
+		if got := closure.At(i, j); got != test.want {
+			t.Errorf("unexpected closure cost from %d to %d: got:%v want:%v", test.from, test.to, got, test.want)
+		}
+	}
+}
+
+func TestDistanceMatrix(t *testing.T) {
+	g := simple.NewUndirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.Edge{
+		{F: simple.Node(0), T: simple.Node(1), W: 4},
+		{F: simple.Node(0), T: simple.Node(2), W: 1},
+		{F: simple.Node(2), T: simple.Node(1), W: 2},
+		{F: simple.Node(1), T: simple.Node(3), W: 5},
+		{F: simple.Node(5), T: simple.Node(6), W: 1.5},
+	} {
+		g.SetEdge(e)
+	}
+
+	dist, ids := DistanceMatrix(g)
+	if want := []int{0, 1, 2, 3, 5, 6}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("unexpected ids: got:%v want:%v", ids, want)
+	}
+	for i, u := range ids {
+		pt := DijkstraFrom(simple.Node(u), g)
+		for j, v := range ids {
+			got := dist.At(i, j)
+			_, want := pt.To(simple.Node(v))
+			if got != want {
+				t.Errorf("unexpected distance from %d to %d: got:%v want:%v", u, v, got, want)
+			}
+			if got != dist.At(j, i) {
+				t.Errorf("distance matrix not symmetric for %d and %d: %v != %v", u, v, got, dist.At(j, i))
+			}
+		}
+	}
+	if got := dist.At(indexIn(ids, 0), indexIn(ids, 3)); got != 8 {
+		t.Errorf("unexpected distance from 0 to 3: got:%v want:8", got)
+	}
+
+	dist, ids = DistanceMatrix(simple.NewUndirectedGraph(0, math.Inf(1)))
+	if r, c := dist.Dims(); r != 0 || c != 0 || len(ids) != 0 {
+		t.Errorf("unexpected distance matrix for empty graph: dims:%d×%d ids:%v", r, c, ids)
+	}
+}
+
+func indexIn(ids []int, id int) int {
+	for i, v := range ids {
+		if v == id {
+			return i
+		}
+	}
+	return -1
+}
@@ -13,6 +13,7 @@ import (
 	"gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/internal/ordered"
 	"gonum.org/v1/gonum/graph/path/internal/testgraphs"
+	"gonum.org/v1/gonum/graph/simple"
 )
 
 func TestDijkstraFrom(t *testing.T) {
@@ -176,3 +177,49 @@ func TestDijkstraAllPaths(t *testing.T) {
 		}
 	}
 }
+
+func TestDijkstraFiniteAbsent(t *testing.T) {
+	// The graph uses a finite absent weight, so the 0->2 edge
+	// carrying that weight must be treated as absent.
+	const absent = 1e9
+	g := simple.NewDirectedGraph(0, absent)
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1), W: 1})
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(2), W: absent})
+	g.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(3), W: 2})
+
+	pt := DijkstraFrom(simple.Node(0), g)
+	if w := pt.WeightTo(simple.Node(2)); !math.IsInf(w, 1) {
+		t.Errorf("unexpected weight to node with absent edge: got:%v want:%v", w, math.Inf(1))
+	}
+	if p, _ := pt.To(simple.Node(2)); p != nil {
+		t.Errorf("unexpected path to node with absent edge: got:%v want:<nil>", p)
+	}
+	p, w := pt.To(simple.Node(3))
+	if w != 3 {
+		t.Errorf("unexpected weight to reachable node: got:%v want:3", w)
+	}
+	if want := []graph.Node{simple.Node(0), simple.Node(1), simple.Node(3)}; !reflect.DeepEqual(p, want) {
+		t.Errorf("unexpected path to reachable node: got:%v want:%v", p, want)
+	}
+}
+
+func TestDijkstraInducedView(t *testing.T) {
+	// The shortest path in the view must use the weights of the
+	// underlying graph rather than uniform costs, and must not
+	// pass through node 3, which is outside the view.
+	g := simple.NewDirectedGraph(0, math.Inf(1))
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1), W: 10})
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(2), W: 1})
+	g.SetEdge(simple.Edge{F: simple.Node(2), T: simple.Node(1), W: 1})
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(3), W: 0.1})
+	g.SetEdge(simple.Edge{F: simple.Node(3), T: simple.Node(1), W: 0.1})
+	view := simple.InducedView(g, map[int]bool{0: true, 1: true, 2: true})
+
+	p, w := DijkstraFrom(simple.Node(0), view).To(simple.Node(1))
+	if w != 2 {
+		t.Errorf("unexpected weight in view: got:%v want:2", w)
+	}
+	if want := []graph.Node{simple.Node(0), simple.Node(2), simple.Node(1)}; !reflect.DeepEqual(p, want) {
+		t.Errorf("unexpected path in view: got:%v want:%v", p, want)
+	}
+}
@@ -3,4 +3,9 @@
 // license that can be found in the LICENSE file.
 
 // Package path provides graph path finding functions.
+//
+// Graphs that report the weight they use to indicate an absent edge with an
+// Absent method, such as the graphs in the simple package, have any edge
+// carrying that weight treated as absent by the shortest path and minimum
+// spanning tree functions, whatever the sentinel value is.
 package path // import "gonum.org/v1/gonum/graph/path"
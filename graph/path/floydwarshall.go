@@ -12,12 +12,7 @@ import "gonum.org/v1/gonum/graph"
 //
 // The time complexity of FloydWarshall is O(|V|^3).
 func FloydWarshall(g graph.Graph) (paths AllShortest, ok bool) {
-	var weight Weighting
-	if wg, ok := g.(graph.Weighter); ok {
-		weight = wg.Weight
-	} else {
-		weight = UniformCost(g)
-	}
+	weight := weightingFor(g)
 
 	nodes := g.Nodes()
 	paths = newAllShortest(nodes, true)
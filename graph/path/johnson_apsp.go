@@ -22,11 +22,7 @@ func JohnsonAllPaths(g graph.Graph) (paths AllShortest, ok bool) {
 		from:   g.From,
 		edgeTo: g.Edge,
 	}
-	if wg, ok := g.(graph.Weighter); ok {
-		jg.weight = wg.Weight
-	} else {
-		jg.weight = UniformCost(g)
-	}
+	jg.weight = weightingFor(g)
 
 	paths = newAllShortest(g.Nodes(), false)
 
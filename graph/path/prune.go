@@ -0,0 +1,71 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// PruneEdges returns a copy of g with the edges weighing less than threshold
+// removed, except where removing them would disconnect a connected component
+// of g. In particular, bridges of g are retained regardless of their weight.
+//
+// The edges at or above threshold are all retained. Components of that graph
+// are then reconnected by adding back low-weight edges in descending weight
+// order, as for a maximum spanning forest, so the retained low-weight edges
+// are the heaviest that preserve the connectivity of g. If g does not
+// implement graph.Weighter, edge weights are obtained from the edges' Weight
+// method.
+func PruneEdges(g graph.Undirected, threshold float64) graph.Undirected {
+	var weight func(u, v graph.Node) float64
+	if wg, ok := g.(graph.Weighter); ok {
+		weight = func(u, v graph.Node) float64 {
+			w, ok := wg.Weight(u, v)
+			if !ok {
+				panic("prune: unexpected invalid weight")
+			}
+			return w
+		}
+	} else {
+		weight = func(u, v graph.Node) float64 { return g.Edge(u, v).Weight() }
+	}
+
+	dst := simple.NewUndirectedGraph(0, math.Inf(1))
+	ds := newDisjointSet()
+	nodes := g.Nodes()
+	for _, n := range nodes {
+		dst.AddNode(n)
+		ds.makeSet(n.ID())
+	}
+
+	var weak []simple.Edge
+	for _, u := range nodes {
+		for _, v := range g.From(u) {
+			if u.ID() > v.ID() {
+				continue
+			}
+			e := simple.Edge{F: u, T: v, W: weight(u, v)}
+			if e.W < threshold {
+				weak = append(weak, e)
+				continue
+			}
+			dst.SetEdge(e)
+			ds.union(ds.find(u.ID()), ds.find(v.ID()))
+		}
+	}
+
+	sort.Sort(sort.Reverse(byWeight(weak)))
+	for _, e := range weak {
+		if s1, s2 := ds.find(e.F.ID()), ds.find(e.T.ID()); s1 != s2 {
+			ds.union(s1, s2)
+			dst.SetEdge(e)
+		}
+	}
+	return dst
+}
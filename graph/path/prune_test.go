@@ -0,0 +1,56 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+func TestPruneEdges(t *testing.T) {
+	// Two triangle clusters joined by a weak bridge 2-3.
+	g := simple.NewUndirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.Edge{
+		{F: simple.Node(0), T: simple.Node(1), W: 5},
+		{F: simple.Node(1), T: simple.Node(2), W: 5},
+		{F: simple.Node(0), T: simple.Node(2), W: 0.5},
+
+		{F: simple.Node(3), T: simple.Node(4), W: 4},
+		{F: simple.Node(4), T: simple.Node(5), W: 0.2},
+		{F: simple.Node(3), T: simple.Node(5), W: 6},
+
+		{F: simple.Node(2), T: simple.Node(3), W: 0.1},
+	} {
+		g.SetEdge(e)
+	}
+
+	got := PruneEdges(g, 1)
+
+	if len(got.Nodes()) != len(g.Nodes()) {
+		t.Errorf("unexpected node count: got:%d want:%d", len(got.Nodes()), len(g.Nodes()))
+	}
+	if !got.HasEdgeBetween(simple.Node(2), simple.Node(3)) {
+		t.Error("weak bridge was removed")
+	}
+	for _, e := range [][2]int{{0, 2}, {4, 5}} {
+		if got.HasEdgeBetween(simple.Node(e[0]), simple.Node(e[1])) {
+			t.Errorf("weak intra-cluster edge %v was retained", e)
+		}
+	}
+	for _, e := range [][2]int{{0, 1}, {1, 2}, {3, 4}, {3, 5}} {
+		if !got.HasEdgeBetween(simple.Node(e[0]), simple.Node(e[1])) {
+			t.Errorf("strong edge %v was removed", e)
+		}
+	}
+	if w := got.EdgeBetween(simple.Node(3), simple.Node(5)).Weight(); w != 6 {
+		t.Errorf("unexpected retained edge weight: got:%v want:6", w)
+	}
+	if cc := topo.ConnectedComponents(got); len(cc) != 1 {
+		t.Errorf("pruned graph is not connected: %v", cc)
+	}
+}
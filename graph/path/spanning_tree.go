@@ -39,12 +39,17 @@ func Prim(dst graph.UndirectedBuilder, g UndirectedWeighter) float64 {
 		heap.Push(q, simple.Edge{F: u, W: math.Inf(1)})
 	}
 
+	isAbsent := absentEdgeFunc(g)
+
 	u := nodes[0]
 	for _, v := range g.From(u) {
 		w, ok := g.Weight(u, v)
 		if !ok {
 			panic("prim: unexpected invalid weight")
 		}
+		if isAbsent(w) {
+			continue
+		}
 		q.update(v, u, w)
 	}
 
@@ -63,7 +68,7 @@ func Prim(dst graph.UndirectedBuilder, g UndirectedWeighter) float64 {
 				if !ok {
 					panic("prim: unexpected invalid weight")
 				}
-				if w < key {
+				if w < key && !isAbsent(w) {
 					q.update(n, u, w)
 				}
 			}
@@ -146,6 +151,8 @@ type UndirectedWeightLister interface {
 // a minimum spanning forest will be constructed in dst and the sum of minimum
 // spanning tree weights will be returned.
 func Kruskal(dst graph.UndirectedBuilder, g UndirectedWeightLister) float64 {
+	isAbsent := absentEdgeFunc(g)
+
 	edges := g.Edges()
 	ascend := make([]simple.Edge, 0, len(edges))
 	for _, e := range edges {
@@ -155,6 +162,9 @@ func Kruskal(dst graph.UndirectedBuilder, g UndirectedWeightLister) float64 {
 		if !ok {
 			panic("kruskal: unexpected invalid weight")
 		}
+		if isAbsent(w) {
+			continue
+		}
 		ascend = append(ascend, simple.Edge{F: u, T: v, W: w})
 	}
 	sort.Sort(byWeight(ascend))
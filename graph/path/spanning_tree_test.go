@@ -43,6 +43,23 @@ var spanningTreeTests = []struct {
 		graph: func() spanningGraph { return simple.NewUndirectedGraph(0, math.Inf(1)) },
 		want:  0,
 	},
+	{
+		// The A--C edge carries the graph's absent weight
+		// and so must not be used.
+		name:  "Negative absent sentinel",
+		graph: func() spanningGraph { return simple.NewUndirectedGraph(0, -1) },
+		edges: []simple.Edge{
+			{F: simple.Node('A'), T: simple.Node('B'), W: 1},
+			{F: simple.Node('A'), T: simple.Node('C'), W: -1},
+			{F: simple.Node('B'), T: simple.Node('C'), W: 2},
+		},
+
+		want: 3,
+		treeEdges: []simple.Edge{
+			{F: simple.Node('A'), T: simple.Node('B'), W: 1},
+			{F: simple.Node('B'), T: simple.Node('C'), W: 2},
+		},
+	},
 	{
 		// https://upload.wikimedia.org/wikipedia/commons/f/f7/Prim%27s_algorithm.svg
 		// Modified to make edge weights unique; A--B is increased to 2.5 otherwise
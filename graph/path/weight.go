@@ -30,6 +30,55 @@ func UniformCost(g graph.Graph) Weighting {
 	}
 }
 
+// absenter is a graph that reports the weight used to indicate an absent edge.
+type absenter interface {
+	Absent() float64
+}
+
+// weightingFor returns a Weighting for g. If g does not implement graph.Weighter,
+// UniformCost is used. If g reports its absent edge weight with an Absent method,
+// any edge between distinct nodes carrying that weight is given an infinite
+// weight so that it is treated as absent.
+func weightingFor(g graph.Graph) Weighting {
+	wg, ok := g.(graph.Weighter)
+	if !ok {
+		return UniformCost(g)
+	}
+	a, ok := g.(absenter)
+	if !ok {
+		return wg.Weight
+	}
+	absent := a.Absent()
+	if math.IsInf(absent, 1) {
+		return wg.Weight
+	}
+	return func(x, y graph.Node) (w float64, ok bool) {
+		w, ok = wg.Weight(x, y)
+		if x.ID() != y.ID() && isAbsent(w, absent) {
+			return math.Inf(1), ok
+		}
+		return w, ok
+	}
+}
+
+// absentEdgeFunc returns a function reporting whether an edge weight in g is
+// the absent edge weight reported by g's Absent method. If g does not have an
+// Absent method, the returned function always returns false.
+func absentEdgeFunc(g graph.Graph) func(w float64) bool {
+	a, ok := g.(absenter)
+	if !ok {
+		return func(float64) bool { return false }
+	}
+	absent := a.Absent()
+	return func(w float64) bool { return isAbsent(w, absent) }
+}
+
+// isAbsent returns whether w is the absent weight value where NaN values are
+// equalable.
+func isAbsent(w, absent float64) bool {
+	return w == absent || (math.IsNaN(w) && math.IsNaN(absent))
+}
+
 // Heuristic returns an estimate of the cost of travelling between two nodes.
 type Heuristic func(x, y graph.Node) float64
 
@@ -0,0 +1,192 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"container/heap"
+	"errors"
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// KShortestPaths returns up to k shortest loopless paths from the node with ID
+// from to the node with ID to in the graph g, along with their costs, using
+// Yen's algorithm. The paths are returned as sequences of node IDs in order of
+// increasing cost. If fewer than k paths exist, all the available paths are
+// returned. If the graph does not implement graph.Weighter, UniformCost is
+// used.
+//
+// KShortestPaths returns an error if either node is not in g or if a negative
+// edge weight is encountered.
+func KShortestPaths(g graph.Graph, from, to, k int) ([][]int, []float64, error) {
+	if !g.Has(simple.Node(from)) || !g.Has(simple.Node(to)) {
+		return nil, nil, errors.New("path: node not in graph")
+	}
+	if k < 1 {
+		return nil, nil, nil
+	}
+	weight := weightingFor(g)
+	y := yenKSP{g: g, weight: weight}
+
+	first, cost, err := y.shortest(from, to, nil, nil)
+	if err != nil || first == nil {
+		return nil, nil, err
+	}
+	paths := [][]int{first}
+	costs := []float64{cost}
+
+	var candidates []yenPath
+	for len(paths) < k {
+		prev := paths[len(paths)-1]
+		for i := 0; i < len(prev)-1; i++ {
+			spur := prev[i]
+			root := prev[:i+1]
+
+			blockedEdges := make(map[[2]int]bool)
+			for _, p := range paths {
+				if len(p) > i && equalIDs(p[:i+1], root) {
+					blockedEdges[[2]int{p[i], p[i+1]}] = true
+				}
+			}
+			blockedNodes := make(map[int]bool, i)
+			for _, id := range root[:i] {
+				blockedNodes[id] = true
+			}
+
+			spurPath, spurCost, err := y.shortest(spur, to, blockedNodes, blockedEdges)
+			if err != nil {
+				return nil, nil, err
+			}
+			if spurPath == nil {
+				continue
+			}
+			var rootCost float64
+			for j := 0; j < i; j++ {
+				w, _ := weight(simple.Node(root[j]), simple.Node(root[j+1]))
+				rootCost += w
+			}
+			candidate := make([]int, 0, len(root)+len(spurPath)-1)
+			candidate = append(candidate, root...)
+			candidate = append(candidate, spurPath[1:]...)
+			if !containsPath(paths, candidate) && !containsCandidate(candidates, candidate) {
+				candidates = append(candidates, yenPath{path: candidate, cost: rootCost + spurCost})
+			}
+		}
+		if len(candidates) == 0 {
+			break
+		}
+
+		best := 0
+		for i, c := range candidates[1:] {
+			if c.cost < candidates[best].cost {
+				best = i + 1
+			}
+		}
+		paths = append(paths, candidates[best].path)
+		costs = append(costs, candidates[best].cost)
+		candidates = append(candidates[:best], candidates[best+1:]...)
+	}
+
+	return paths, costs, nil
+}
+
+// yenKSP holds the graph and weighting used by KShortestPaths.
+type yenKSP struct {
+	g      graph.Graph
+	weight Weighting
+}
+
+// yenPath is a candidate path and its cost.
+type yenPath struct {
+	path []int
+	cost float64
+}
+
+// shortest returns the shortest path from the node with ID from to the node
+// with ID to, avoiding the blocked nodes and edges, and its cost. If no path
+// exists shortest returns a nil path.
+func (y yenKSP) shortest(from, to int, blockedNodes map[int]bool, blockedEdges map[[2]int]bool) ([]int, float64, error) {
+	dist := map[int]float64{from: 0}
+	prev := make(map[int]int)
+	Q := priorityQueue{{node: simple.Node(from), dist: 0}}
+	for Q.Len() != 0 {
+		mid := heap.Pop(&Q).(distanceNode)
+		uid := mid.node.ID()
+		if mid.dist > dist[uid] {
+			continue
+		}
+		if uid == to {
+			break
+		}
+		for _, v := range y.g.From(mid.node) {
+			vid := v.ID()
+			if blockedNodes[vid] || blockedEdges[[2]int{uid, vid}] {
+				continue
+			}
+			w, ok := y.weight(mid.node, v)
+			if !ok {
+				panic("yen: unexpected invalid weight")
+			}
+			if w < 0 {
+				return nil, 0, errors.New("path: negative edge weight")
+			}
+			joint := mid.dist + w
+			if d, ok := dist[vid]; !ok || joint < d {
+				dist[vid] = joint
+				prev[vid] = uid
+				heap.Push(&Q, distanceNode{node: v, dist: joint})
+			}
+		}
+	}
+
+	cost, ok := dist[to]
+	if !ok || math.IsInf(cost, 1) {
+		return nil, 0, nil
+	}
+	path := []int{to}
+	for id := to; id != from; {
+		id = prev[id]
+		path = append(path, id)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path, cost, nil
+}
+
+// equalIDs returns whether a and b hold the same IDs in the same order.
+func equalIDs(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, id := range a {
+		if id != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// containsPath returns whether p is in paths.
+func containsPath(paths [][]int, p []int) bool {
+	for _, q := range paths {
+		if equalIDs(p, q) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsCandidate returns whether p is the path of one of the candidates.
+func containsCandidate(candidates []yenPath, p []int) bool {
+	for _, c := range candidates {
+		if equalIDs(p, c.path) {
+			return true
+		}
+	}
+	return false
+}
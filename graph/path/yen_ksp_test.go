@@ -0,0 +1,74 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestKShortestPaths(t *testing.T) {
+	g := simple.NewDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.Edge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(3), W: 1},
+		{F: simple.Node(0), T: simple.Node(2), W: 2},
+		{F: simple.Node(2), T: simple.Node(3), W: 3},
+		{F: simple.Node(0), T: simple.Node(3), W: 10},
+		{F: simple.Node(1), T: simple.Node(2), W: 4},
+	} {
+		g.SetEdge(e)
+	}
+
+	for _, test := range []struct {
+		k         int
+		wantPaths [][]int
+		wantCosts []float64
+	}{
+		{
+			k:         1,
+			wantPaths: [][]int{{0, 1, 3}},
+			wantCosts: []float64{2},
+		},
+		{
+			k:         2,
+			wantPaths: [][]int{{0, 1, 3}, {0, 2, 3}},
+			wantCosts: []float64{2, 5},
+		},
+		{
+			// Only four loopless paths exist.
+			k:         10,
+			wantPaths: [][]int{{0, 1, 3}, {0, 2, 3}, {0, 1, 2, 3}, {0, 3}},
+			wantCosts: []float64{2, 5, 8, 10},
+		},
+	} {
+		paths, costs, err := KShortestPaths(g, 0, 3, test.k)
+		if err != nil {
+			t.Fatalf("unexpected error for k=%d: %v", test.k, err)
+		}
+		if !reflect.DeepEqual(paths, test.wantPaths) {
+			t.Errorf("unexpected paths for k=%d: got:%v want:%v", test.k, paths, test.wantPaths)
+		}
+		if !reflect.DeepEqual(costs, test.wantCosts) {
+			t.Errorf("unexpected costs for k=%d: got:%v want:%v", test.k, costs, test.wantCosts)
+		}
+	}
+
+	if _, _, err := KShortestPaths(g, 0, 4, 2); err == nil {
+		t.Error("expected error for missing node")
+	}
+	paths, _, err := KShortestPaths(g, 3, 0, 2)
+	if err != nil || paths != nil {
+		t.Errorf("unexpected result for unreachable target: paths=%v err=%v", paths, err)
+	}
+
+	g.SetEdge(simple.Edge{F: simple.Node(2), T: simple.Node(1), W: -1})
+	if _, _, err := KShortestPaths(g, 0, 3, 2); err == nil {
+		t.Error("expected error for negative edge weight")
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"fmt"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/mat"
+)
+
+// AdjacencyMatrix returns the weighted adjacency matrix of g over the given
+// nodes. Element (i, j) is the weight of the edge from nodes[i] to nodes[j],
+// or zero if there is no such edge, so the matrix of a directed graph need
+// not be symmetric. Edges to nodes not in nodes are ignored, and nodes not
+// in g have all-zero rows and columns. If nodes is nil, the nodes of g sorted
+// by ascending ID are used. AdjacencyMatrix will panic if nodes holds a node
+// more than once.
+//
+// If g is a graph.Weighter the weights are obtained from its Weight method,
+// otherwise they are obtained from the edges' Weight method. If g has an
+// Absent method, edges carrying the absent weight it returns are stored as
+// zero, as for a missing edge; a NaN absent weight matches NaN edge weights.
+func AdjacencyMatrix(g graph.Graph, nodes []graph.Node) *mat.Dense {
+	if nodes == nil {
+		nodes = g.Nodes()
+		sort.Sort(ordered.ByID(nodes))
+	}
+	if len(nodes) == 0 {
+		return &mat.Dense{}
+	}
+	indexOf := make(map[int]int, len(nodes))
+	for i, n := range nodes {
+		if _, exists := indexOf[n.ID()]; exists {
+			panic(fmt.Sprintf("simple: duplicate node ID %d", n.ID()))
+		}
+		indexOf[n.ID()] = i
+	}
+
+	isAbsent := absentWeightFunc(g)
+	weight := edgeWeightFunc(g)
+	a := mat.NewDense(len(nodes), len(nodes), nil)
+	for i, u := range nodes {
+		if !g.Has(u) {
+			continue
+		}
+		for _, v := range g.From(u) {
+			j, ok := indexOf[v.ID()]
+			if !ok {
+				continue
+			}
+			if w := weight(u, v); !isAbsent(w) {
+				a.Set(i, j, w)
+			}
+		}
+	}
+	return a
+}
+
+// absentWeightFunc returns a function reporting whether an edge weight is
+// the absent weight returned by the Absent method of g, where a NaN absent
+// weight matches NaN edge weights. If g has no Absent method the returned
+// function always returns false.
+func absentWeightFunc(g graph.Graph) func(w float64) bool {
+	ag, ok := g.(interface {
+		Absent() float64
+	})
+	if !ok {
+		return func(float64) bool { return false }
+	}
+	absent := ag.Absent()
+	return func(w float64) bool { return isSame(w, absent) }
+}
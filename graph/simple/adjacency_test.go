@@ -0,0 +1,119 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestAdjacencyMatrix(t *testing.T) {
+	dg := NewDirectedGraph(0, math.Inf(1))
+	for _, e := range []Edge{
+		{F: Node(0), T: Node(1), W: 1},
+		{F: Node(1), T: Node(0), W: 2},
+		{F: Node(1), T: Node(3), W: 4},
+		{F: Node(3), T: Node(2), W: 0.5},
+	} {
+		dg.SetEdge(e)
+	}
+	ug := NewUndirectedGraph(0, math.Inf(1))
+	for _, e := range []Edge{
+		{F: Node(0), T: Node(1), W: 1},
+		{F: Node(1), T: Node(3), W: 4},
+		{F: Node(3), T: Node(2), W: 0.5},
+	} {
+		ug.SetEdge(e)
+	}
+
+	for _, test := range []struct {
+		name  string
+		g     graph.Graph
+		nodes []graph.Node
+		want  *mat.Dense
+	}{
+		{
+			name: "directed",
+			g:    dg,
+			want: mat.NewDense(4, 4, []float64{
+				0, 1, 0, 0,
+				2, 0, 0, 4,
+				0, 0, 0, 0,
+				0, 0, 0.5, 0,
+			}),
+		},
+		{
+			name:  "directed subset",
+			g:     dg,
+			nodes: []graph.Node{Node(3), Node(1), Node(2)},
+			want: mat.NewDense(3, 3, []float64{
+				0, 0, 0.5,
+				4, 0, 0,
+				0, 0, 0,
+			}),
+		},
+		{
+			name: "undirected",
+			g:    ug,
+			want: mat.NewDense(4, 4, []float64{
+				0, 1, 0, 0,
+				1, 0, 0, 4,
+				0, 0, 0, 0.5,
+				0, 4, 0.5, 0,
+			}),
+		},
+		{
+			name:  "absent node",
+			g:     ug,
+			nodes: []graph.Node{Node(1), Node(5), Node(0)},
+			want: mat.NewDense(3, 3, []float64{
+				0, 0, 1,
+				0, 0, 0,
+				1, 0, 0,
+			}),
+		},
+	} {
+		got := AdjacencyMatrix(test.g, test.nodes)
+		if !mat.Equal(got, test.want) {
+			t.Errorf("unexpected adjacency matrix for %s test:\ngot:\n%v\nwant:\n%v",
+				test.name, mat.Formatted(got), mat.Formatted(test.want))
+		}
+	}
+
+	// Edges carrying the absent weight are stored as zero,
+	// with NaN matching a NaN absent weight.
+	for _, absent := range []float64{math.Inf(1), -1, math.NaN()} {
+		g := NewUndirectedGraph(0, absent)
+		g.SetEdge(Edge{F: Node(0), T: Node(1), W: absent})
+		g.SetEdge(Edge{F: Node(1), T: Node(2), W: 3})
+		got := AdjacencyMatrix(g, nil)
+		want := mat.NewDense(3, 3, []float64{
+			0, 0, 0,
+			0, 0, 3,
+			0, 3, 0,
+		})
+		if !mat.Equal(got, want) {
+			t.Errorf("unexpected adjacency matrix for absent weight %v:\ngot:\n%v\nwant:\n%v",
+				absent, mat.Formatted(got), mat.Formatted(want))
+		}
+	}
+
+	a := AdjacencyMatrix(NewDirectedGraph(0, math.Inf(1)), nil)
+	if r, c := a.Dims(); r != 0 || c != 0 {
+		t.Errorf("unexpected adjacency matrix dimensions for empty graph: %d×%d", r, c)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected panic for duplicate node")
+			}
+		}()
+		AdjacencyMatrix(dg, []graph.Node{Node(0), Node(0)})
+	}()
+}
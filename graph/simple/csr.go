@@ -0,0 +1,65 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// CSR returns the compressed sparse row representation of the weighted
+// adjacency of g. Rows and columns are indexed by the position of the node
+// ID in nodeIDs, which is sorted in ascending order. The edges from the node
+// of row i are held in indices[indptr[i]:indptr[i+1]] as column indices in
+// ascending order, with their weights in the corresponding elements of data.
+//
+// If g is a graph.Weighter the weights are obtained from its Weight method,
+// otherwise they are obtained from the edges' Weight method. If g has an
+// Absent method, edges carrying the absent weight it returns are not stored,
+// as for AdjacencyMatrix.
+func CSR(g graph.Directed) (indptr, indices []int, data []float64, nodeIDs []int) {
+	nodes := g.Nodes()
+	sort.Sort(ordered.ByID(nodes))
+	nodeIDs = make([]int, len(nodes))
+	indexOf := make(map[int]int, len(nodes))
+	for i, n := range nodes {
+		nodeIDs[i] = n.ID()
+		indexOf[n.ID()] = i
+	}
+
+	isAbsent := absentWeightFunc(g)
+	weight := edgeWeightFunc(g)
+	indptr = make([]int, len(nodes)+1)
+	for i, u := range nodes {
+		to := g.From(u)
+		sort.Sort(ordered.ByID(to))
+		for _, v := range to {
+			w := weight(u, v)
+			if isAbsent(w) {
+				continue
+			}
+			indices = append(indices, indexOf[v.ID()])
+			data = append(data, w)
+		}
+		indptr[i+1] = len(indices)
+	}
+	return indptr, indices, data, nodeIDs
+}
+
+// edgeWeightFunc returns a function returning the weight of the edge from
+// u to v in g. The edge must exist.
+func edgeWeightFunc(g graph.Graph) func(u, v graph.Node) float64 {
+	if wg, ok := g.(graph.Weighter); ok {
+		return func(u, v graph.Node) float64 {
+			w, _ := wg.Weight(u, v)
+			return w
+		}
+	}
+	return func(u, v graph.Node) float64 {
+		return g.Edge(u, v).Weight()
+	}
+}
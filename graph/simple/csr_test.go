@@ -0,0 +1,49 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestCSR(t *testing.T) {
+	g := generateDummyGraph()
+	g.SetEdge(Edge{F: Node(0), T: Node(1), W: 0.5})
+
+	indptr, indices, data, nodeIDs := CSR(g)
+
+	if want := []int{0, 1, 2}; !reflect.DeepEqual(nodeIDs, want) {
+		t.Errorf("unexpected node IDs: got:%v want:%v", nodeIDs, want)
+	}
+	if want := []int{0, 2, 3, 5}; !reflect.DeepEqual(indptr, want) {
+		t.Errorf("unexpected indptr: got:%v want:%v", indptr, want)
+	}
+	if want := []int{1, 2, 0, 0, 1}; !reflect.DeepEqual(indices, want) {
+		t.Errorf("unexpected indices: got:%v want:%v", indices, want)
+	}
+	if want := []float64{0.5, 1, 1, 1, 1}; !reflect.DeepEqual(data, want) {
+		t.Errorf("unexpected data: got:%v want:%v", data, want)
+	}
+
+	// Edges carrying the absent weight are not stored.
+	for _, absent := range []float64{2, math.NaN()} {
+		g := NewDirectedGraph(0, absent)
+		g.SetEdge(Edge{F: Node(0), T: Node(1), W: absent})
+		g.SetEdge(Edge{F: Node(0), T: Node(2), W: 3})
+		g.SetEdge(Edge{F: Node(2), T: Node(1), W: absent})
+		indptr, indices, data, _ := CSR(g)
+		if want := []int{0, 1, 1, 1}; !reflect.DeepEqual(indptr, want) {
+			t.Errorf("unexpected indptr with absent weight %v: got:%v want:%v", absent, indptr, want)
+		}
+		if want := []int{2}; !reflect.DeepEqual(indices, want) {
+			t.Errorf("unexpected indices with absent weight %v: got:%v want:%v", absent, indices, want)
+		}
+		if want := []float64{3}; !reflect.DeepEqual(data, want) {
+			t.Errorf("unexpected data with absent weight %v: got:%v want:%v", absent, data, want)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import "gonum.org/v1/gonum/graph"
+
+// AllDegrees returns the degree of every node in g keyed on node ID. For
+// directed graphs the degree is the sum of the in and out degrees. The
+// degrees are computed in a single pass over the adjacency of g.
+func AllDegrees(g graph.Graph) map[int]int {
+	if g, ok := g.(graph.Directed); ok {
+		in, out := inOutDegrees(g)
+		for id, d := range out {
+			in[id] += d
+		}
+		return in
+	}
+	nodes := g.Nodes()
+	deg := make(map[int]int, len(nodes))
+	for _, u := range nodes {
+		deg[u.ID()] = len(g.From(u))
+	}
+	return deg
+}
+
+// AllInDegrees returns the in degree of every node in g keyed on node ID.
+func AllInDegrees(g graph.Directed) map[int]int {
+	in, _ := inOutDegrees(g)
+	return in
+}
+
+// AllOutDegrees returns the out degree of every node in g keyed on node ID.
+func AllOutDegrees(g graph.Directed) map[int]int {
+	_, out := inOutDegrees(g)
+	return out
+}
+
+// inOutDegrees returns the in and out degrees of every node in g.
+func inOutDegrees(g graph.Directed) (in, out map[int]int) {
+	nodes := g.Nodes()
+	in = make(map[int]int, len(nodes))
+	out = make(map[int]int, len(nodes))
+	for _, u := range nodes {
+		in[u.ID()] = 0
+	}
+	for _, u := range nodes {
+		to := g.From(u)
+		out[u.ID()] = len(to)
+		for _, v := range to {
+			in[v.ID()]++
+		}
+	}
+	return in, out
+}
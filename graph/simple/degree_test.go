@@ -0,0 +1,45 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestAllDegrees(t *testing.T) {
+	g := generateDummyGraph()
+	g.AddNode(Node(3))
+
+	if got, want := AllInDegrees(g), map[int]int{0: 2, 1: 1, 2: 1, 3: 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected in degrees: got:%v want:%v", got, want)
+	}
+	if got, want := AllOutDegrees(g), map[int]int{0: 1, 1: 1, 2: 2, 3: 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected out degrees: got:%v want:%v", got, want)
+	}
+	got := AllDegrees(g)
+	if want := map[int]int{0: 3, 1: 2, 2: 3, 3: 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected degrees: got:%v want:%v", got, want)
+	}
+	for id, d := range got {
+		if want := g.Degree(Node(id)); d != want {
+			t.Errorf("degree mismatch for node %d: got:%d want:%d", id, d, want)
+		}
+	}
+
+	u := NewUndirectedGraph(0, math.Inf(1))
+	for _, e := range []Edge{
+		{F: Node(0), T: Node(1)},
+		{F: Node(0), T: Node(2)},
+		{F: Node(1), T: Node(2)},
+		{F: Node(2), T: Node(3)},
+	} {
+		u.SetEdge(e)
+	}
+	if got, want := AllDegrees(u), map[int]int{0: 2, 1: 2, 2: 3, 3: 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected undirected degrees: got:%v want:%v", got, want)
+	}
+}
@@ -231,6 +231,16 @@ func (g *DirectedMatrix) RemoveEdge(e graph.Edge) {
 	g.mat.Set(fid, tid, g.absent)
 }
 
+// Self returns the weight returned by Weight for self edges.
+func (g *DirectedMatrix) Self() float64 {
+	return g.self
+}
+
+// Absent returns the weight returned by Weight for absent edges.
+func (g *DirectedMatrix) Absent() float64 {
+	return g.absent
+}
+
 // Degree returns the in+out degree of n in g.
 func (g *DirectedMatrix) Degree(n graph.Node) int {
 	id := n.ID()
@@ -200,6 +200,16 @@ func (g *UndirectedMatrix) RemoveEdge(e graph.Edge) {
 	g.mat.SetSym(fid, tid, g.absent)
 }
 
+// Self returns the weight returned by Weight for self edges.
+func (g *UndirectedMatrix) Self() float64 {
+	return g.self
+}
+
+// Absent returns the weight returned by Weight for absent edges.
+func (g *UndirectedMatrix) Absent() float64 {
+	return g.absent
+}
+
 // Degree returns the degree of n in g.
 func (g *UndirectedMatrix) Degree(n graph.Node) int {
 	id := n.ID()
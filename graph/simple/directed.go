@@ -105,6 +105,79 @@ func (g *DirectedGraph) SetEdge(e graph.Edge) {
 	g.to[tid][fid] = e
 }
 
+// SetEdges adds the edges in edges to the graph, adding any nodes that do not
+// exist. The result is the same as calling SetEdge for each edge in order, but
+// the graph's maps are allocated once with the capacity needed for the edges.
+// It will panic, without modifying the graph, if the IDs of the From and To
+// of any edge are equal.
+func (g *DirectedGraph) SetEdges(edges []graph.Edge) {
+	var (
+		added   = make(map[int]graph.Node)
+		fromDeg = make(map[int]int)
+		toDeg   = make(map[int]int)
+	)
+	for _, e := range edges {
+		from, to := e.From(), e.To()
+		fid, tid := from.ID(), to.ID()
+		if fid == tid {
+			panic("simple: adding self edge")
+		}
+		if _, ok := g.nodes[fid]; !ok {
+			if _, ok := added[fid]; !ok {
+				added[fid] = from
+			}
+		}
+		if _, ok := g.nodes[tid]; !ok {
+			if _, ok := added[tid]; !ok {
+				added[tid] = to
+			}
+		}
+		fromDeg[fid]++
+		toDeg[tid]++
+	}
+
+	if len(added) != 0 {
+		n := len(g.nodes) + len(added)
+		nodes := make(map[int]graph.Node, n)
+		from := make(map[int]map[int]graph.Edge, n)
+		to := make(map[int]map[int]graph.Edge, n)
+		for id, u := range g.nodes {
+			nodes[id] = u
+			from[id] = g.from[id]
+			to[id] = g.to[id]
+		}
+		g.nodes, g.from, g.to = nodes, from, to
+		for id, u := range added {
+			g.nodes[id] = u
+			g.from[id] = make(map[int]graph.Edge, fromDeg[id])
+			g.to[id] = make(map[int]graph.Edge, toDeg[id])
+			g.nodeIDs.use(id)
+		}
+	}
+
+	for _, e := range edges {
+		fid, tid := e.From().ID(), e.To().ID()
+		g.from[fid][tid] = e
+		g.to[tid][fid] = e
+	}
+}
+
+// SetWeightedEdge sets the weight of the edge from the node with ID uid to the
+// node with ID vid to w, adding the edge if it does not exist. Nodes that do not
+// exist are added as Node values. The edge is stored as an Edge holding the
+// graph's nodes. It will panic if uid and vid are equal.
+func (g *DirectedGraph) SetWeightedEdge(uid, vid int, w float64) {
+	u, ok := g.nodes[uid]
+	if !ok {
+		u = Node(uid)
+	}
+	v, ok := g.nodes[vid]
+	if !ok {
+		v = Node(vid)
+	}
+	g.SetEdge(Edge{F: u, T: v, W: w})
+}
+
 // RemoveEdge removes e from the graph, leaving the terminal nodes. If the edge does not exist
 // it is a no-op.
 func (g *DirectedGraph) RemoveEdge(e graph.Edge) {
@@ -120,6 +193,17 @@ func (g *DirectedGraph) RemoveEdge(e graph.Edge) {
 	delete(g.to[to.ID()], from.ID())
 }
 
+// RemoveEdgeFromTo removes the edge from the node with ID uid to the node with
+// ID vid, leaving the terminal nodes, and returns whether the edge existed.
+func (g *DirectedGraph) RemoveEdgeFromTo(uid, vid int) bool {
+	if _, ok := g.from[uid][vid]; !ok {
+		return false
+	}
+	delete(g.from[uid], vid)
+	delete(g.to[vid], uid)
+	return true
+}
+
 // Node returns the node in the graph with the given ID.
 func (g *DirectedGraph) Node(id int) graph.Node {
 	return g.nodes[id]
@@ -187,6 +271,30 @@ func (g *DirectedGraph) To(n graph.Node) []graph.Node {
 	return to
 }
 
+// FromIter calls fn for each node in g that can be reached directly from the
+// node with the given ID, stopping if fn returns false. Unlike From, FromIter
+// does not allocate a slice of the nodes. The graph must not be modified
+// during the iteration.
+func (g *DirectedGraph) FromIter(id int, fn func(graph.Node) bool) {
+	for vid := range g.from[id] {
+		if !fn(g.nodes[vid]) {
+			return
+		}
+	}
+}
+
+// ToIter calls fn for each node in g that can reach directly to the node
+// with the given ID, stopping if fn returns false. Unlike To, ToIter does not
+// allocate a slice of the nodes. The graph must not be modified during the
+// iteration.
+func (g *DirectedGraph) ToIter(id int, fn func(graph.Node) bool) {
+	for uid := range g.to[id] {
+		if !fn(g.nodes[uid]) {
+			return
+		}
+	}
+}
+
 // HasEdgeBetween returns whether an edge exists between nodes x and y without
 // considering direction.
 func (g *DirectedGraph) HasEdgeBetween(x, y graph.Node) bool {
@@ -253,6 +361,16 @@ func (g *DirectedGraph) Weight(x, y graph.Node) (w float64, ok bool) {
 	return g.absent, false
 }
 
+// Self returns the weight returned by Weight for self edges.
+func (g *DirectedGraph) Self() float64 {
+	return g.self
+}
+
+// Absent returns the weight returned by Weight for absent edges.
+func (g *DirectedGraph) Absent() float64 {
+	return g.absent
+}
+
 // Degree returns the in+out degree of n in g.
 func (g *DirectedGraph) Degree(n graph.Node) int {
 	if _, ok := g.nodes[n.ID()]; !ok {
@@ -261,3 +379,80 @@ func (g *DirectedGraph) Degree(n graph.Node) int {
 
 	return len(g.from[n.ID()]) + len(g.to[n.ID()])
 }
+
+// InDegree returns the number of edges in g to the node with the given ID.
+// If the node is not in g, InDegree returns -1 to distinguish it from an
+// isolated node. Since g cannot hold self edges, the count excludes the node.
+func (g *DirectedGraph) InDegree(id int) int {
+	if _, ok := g.nodes[id]; !ok {
+		return -1
+	}
+	return len(g.to[id])
+}
+
+// OutDegree returns the number of edges in g from the node with the given ID.
+// If the node is not in g, OutDegree returns -1 to distinguish it from an
+// isolated node. Since g cannot hold self edges, the count excludes the node.
+func (g *DirectedGraph) OutDegree(id int) int {
+	if _, ok := g.nodes[id]; !ok {
+		return -1
+	}
+	return len(g.from[id])
+}
+
+// Copy returns a deep copy of g. The copy holds the same nodes and edges,
+// and has the same self and absent weights and node ID allocation state,
+// but shares no maps with g, so mutating the copy does not affect g.
+func (g *DirectedGraph) Copy() *DirectedGraph {
+	c := &DirectedGraph{
+		nodes: make(map[int]graph.Node, len(g.nodes)),
+		from:  make(map[int]map[int]graph.Edge, len(g.from)),
+		to:    make(map[int]map[int]graph.Edge, len(g.to)),
+
+		self:   g.self,
+		absent: g.absent,
+
+		nodeIDs: g.nodeIDs.clone(),
+	}
+	for id, n := range g.nodes {
+		c.nodes[id] = n
+	}
+	for id, edges := range g.from {
+		c.from[id] = copyEdges(edges)
+	}
+	for id, edges := range g.to {
+		c.to[id] = copyEdges(edges)
+	}
+	return c
+}
+
+// Transpose returns a new graph with the same nodes as g and an edge from v
+// to u with the same weight for every edge from u to v in g. The reversed
+// edges are Edge values holding the nodes of g. The transpose has the same
+// self and absent weights and node ID allocation state as g, and shares no
+// maps with g.
+func (g *DirectedGraph) Transpose() *DirectedGraph {
+	t := &DirectedGraph{
+		nodes: make(map[int]graph.Node, len(g.nodes)),
+		from:  make(map[int]map[int]graph.Edge, len(g.from)),
+		to:    make(map[int]map[int]graph.Edge, len(g.to)),
+
+		self:   g.self,
+		absent: g.absent,
+
+		nodeIDs: g.nodeIDs.clone(),
+	}
+	for id, n := range g.nodes {
+		t.nodes[id] = n
+		t.from[id] = make(map[int]graph.Edge, len(g.to[id]))
+		t.to[id] = make(map[int]graph.Edge, len(g.from[id]))
+	}
+	for uid, edges := range g.from {
+		for vid, e := range edges {
+			r := Edge{F: g.nodes[vid], T: g.nodes[uid], W: e.Weight()}
+			t.from[vid][uid] = r
+			t.to[uid][vid] = r
+		}
+	}
+	return t
+}
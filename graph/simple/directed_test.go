@@ -6,6 +6,7 @@ package simple
 
 import (
 	"math"
+	"math/rand"
 	"testing"
 
 	"gonum.org/v1/gonum/graph"
@@ -61,3 +62,407 @@ func TestIssue123DirectedGraph(t *testing.T) {
 	n2 := Node(g.NewNodeID())
 	g.AddNode(n2)
 }
+
+func TestDirectedGraphSelfAbsent(t *testing.T) {
+	for _, test := range []struct{ self, absent float64 }{
+		{self: 0, absent: math.Inf(1)},
+		{self: -1, absent: 1e9},
+		{self: math.Inf(-1), absent: -2.5},
+	} {
+		g := NewDirectedGraph(test.self, test.absent)
+		if got := g.Self(); got != test.self {
+			t.Errorf("unexpected self weight: got:%v want:%v", got, test.self)
+		}
+		if got := g.Absent(); got != test.absent {
+			t.Errorf("unexpected absent weight: got:%v want:%v", got, test.absent)
+		}
+	}
+}
+
+func TestDirectedGraphCopy(t *testing.T) {
+	g := NewDirectedGraph(0, math.Inf(1))
+	for _, e := range []Edge{
+		{F: Node(0), T: Node(1), W: 2},
+		{F: Node(1), T: Node(2), W: 0.5},
+		{F: Node(2), T: Node(0), W: 1},
+	} {
+		g.SetEdge(e)
+	}
+	g.AddNode(Node(3))
+
+	c := g.Copy()
+	if c.Self() != g.Self() || c.Absent() != g.Absent() {
+		t.Errorf("unexpected self and absent weights: got:%v and %v want:%v and %v", c.Self(), c.Absent(), g.Self(), g.Absent())
+	}
+	if len(c.Nodes()) != 4 || len(c.Edges()) != 3 {
+		t.Fatalf("unexpected copy size: got %d nodes and %d edges want 4 and 3", len(c.Nodes()), len(c.Edges()))
+	}
+	for _, e := range g.Edges() {
+		if w, ok := c.Weight(e.From(), e.To()); !ok || w != e.Weight() {
+			t.Errorf("unexpected weight for copied edge %d->%d: got:%v want:%v", e.From().ID(), e.To().ID(), w, e.Weight())
+		}
+	}
+	if got, want := c.NewNodeID(), g.NewNodeID(); got != want {
+		t.Errorf("unexpected new node ID: got:%d want:%d", got, want)
+	}
+
+	c.RemoveEdge(Edge{F: Node(0), T: Node(1)})
+	c.SetEdge(Edge{F: Node(1), T: Node(0), W: 4})
+	c.RemoveNode(Node(2))
+	c.AddNode(Node(5))
+	if !g.HasEdgeFromTo(Node(0), Node(1)) || g.HasEdgeFromTo(Node(1), Node(0)) {
+		t.Error("mutating copy changed edges of original")
+	}
+	if !g.Has(Node(2)) || g.Has(Node(5)) || len(g.Nodes()) != 4 || len(g.Edges()) != 3 {
+		t.Error("mutating copy changed nodes of original")
+	}
+	if len(g.To(Node(0))) != 1 {
+		t.Errorf("unexpected in-edges of original node 0: %v", g.To(Node(0)))
+	}
+	if got := g.NewNodeID(); got != 4 {
+		t.Errorf("mutating copy changed node ID allocation of original: got:%d want:4", got)
+	}
+}
+
+func TestDirectedGraphHasEdgeFromTo(t *testing.T) {
+	g := NewDirectedGraph(0, math.Inf(1))
+	g.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+	g.AddNode(Node(2))
+
+	for _, test := range []struct {
+		u, v int
+		want bool
+	}{
+		{u: 0, v: 1, want: true},
+		{u: 1, v: 0, want: false},
+		{u: 0, v: 0, want: false},
+		{u: 2, v: 2, want: false},
+		{u: 0, v: 2, want: false},
+		{u: 0, v: 3, want: false},
+		{u: 3, v: 1, want: false},
+	} {
+		if got := g.HasEdgeFromTo(Node(test.u), Node(test.v)); got != test.want {
+			t.Errorf("unexpected result for edge %d->%d: got:%t want:%t", test.u, test.v, got, test.want)
+		}
+	}
+}
+
+func TestDirectedGraphSetWeightedEdge(t *testing.T) {
+	g := NewDirectedGraph(0, math.Inf(1))
+	g.SetWeightedEdge(0, 1, 2)
+	if w, ok := g.Weight(Node(0), Node(1)); !ok || w != 2 {
+		t.Errorf("unexpected weight for new edge: got:%v ok:%t want:2 ok:true", w, ok)
+	}
+	if len(g.Nodes()) != 2 {
+		t.Errorf("unexpected number of nodes: got:%d want:2", len(g.Nodes()))
+	}
+
+	g.SetWeightedEdge(0, 1, 5)
+	if w, ok := g.Weight(Node(0), Node(1)); !ok || w != 5 {
+		t.Errorf("unexpected weight for updated edge: got:%v ok:%t want:5 ok:true", w, ok)
+	}
+	if len(g.Edges()) != 1 || len(g.From(Node(0))) != 1 || len(g.To(Node(1))) != 1 {
+		t.Errorf("updating edge weight changed edge count: %v", g.Edges())
+	}
+	if w, ok := g.Weight(Node(1), Node(0)); ok || !math.IsInf(w, 1) {
+		t.Errorf("unexpected weight for reverse edge: got:%v ok:%t want:+Inf ok:false", w, ok)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for self edge")
+		}
+	}()
+	g.SetWeightedEdge(1, 1, 1)
+}
+
+func TestDirectedGraphSetEdges(t *testing.T) {
+	edges := []graph.Edge{
+		Edge{F: Node(0), T: Node(1), W: 1},
+		Edge{F: Node(1), T: Node(2), W: 2},
+		Edge{F: Node(2), T: Node(0), W: 3},
+		Edge{F: Node(0), T: Node(1), W: 4},
+		Edge{F: Node(5), T: Node(2), W: 5},
+	}
+	want := NewDirectedGraph(0, math.Inf(1))
+	want.AddNode(Node(1))
+	for _, e := range edges {
+		want.SetEdge(e)
+	}
+	got := NewDirectedGraph(0, math.Inf(1))
+	got.AddNode(Node(1))
+	got.SetEdges(edges)
+
+	if len(got.Nodes()) != len(want.Nodes()) || len(got.Edges()) != len(want.Edges()) {
+		t.Fatalf("unexpected graph size: got %d nodes and %d edges want %d and %d",
+			len(got.Nodes()), len(got.Edges()), len(want.Nodes()), len(want.Edges()))
+	}
+	for _, e := range want.Edges() {
+		if w, ok := got.Weight(e.From(), e.To()); !ok || w != e.Weight() {
+			t.Errorf("unexpected weight for edge %d->%d: got:%v want:%v", e.From().ID(), e.To().ID(), w, e.Weight())
+		}
+	}
+	for _, n := range want.Nodes() {
+		if len(got.From(n)) != len(want.From(n)) || len(got.To(n)) != len(want.To(n)) {
+			t.Errorf("unexpected adjacency for node %d", n.ID())
+		}
+	}
+	if got.NewNodeID() != want.NewNodeID() {
+		t.Errorf("unexpected new node ID: got:%d want:%d", got.NewNodeID(), want.NewNodeID())
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected panic for self edge")
+			}
+		}()
+		got.SetEdges([]graph.Edge{Edge{F: Node(7), T: Node(8)}, Edge{F: Node(3), T: Node(3)}})
+	}()
+	if got.Has(Node(7)) {
+		t.Error("graph modified by panicking SetEdges")
+	}
+}
+
+// randomEdges returns n random edges between nodes with IDs in [0, nodes).
+func randomEdges(n, nodes int) []graph.Edge {
+	rnd := rand.New(rand.NewSource(1))
+	edges := make([]graph.Edge, 0, n)
+	for len(edges) < n {
+		u, v := rnd.Intn(nodes), rnd.Intn(nodes)
+		if u != v {
+			edges = append(edges, Edge{F: Node(u), T: Node(v), W: 1})
+		}
+	}
+	return edges
+}
+
+func BenchmarkDirectedGraphSetEdges(b *testing.B) {
+	edges := randomEdges(1e5, 1e4)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g := NewDirectedGraph(0, math.Inf(1))
+		g.SetEdges(edges)
+	}
+}
+
+func BenchmarkDirectedGraphSetEdgeLoop(b *testing.B) {
+	edges := randomEdges(1e5, 1e4)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g := NewDirectedGraph(0, math.Inf(1))
+		for _, e := range edges {
+			g.SetEdge(e)
+		}
+	}
+}
+
+func TestDirectedGraphRemoveEdgeFromTo(t *testing.T) {
+	g := NewDirectedGraph(0, math.Inf(1))
+	g.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+	g.SetEdge(Edge{F: Node(1), T: Node(0), W: 1})
+
+	if !g.RemoveEdgeFromTo(0, 1) {
+		t.Error("present edge not reported as removed")
+	}
+	if g.HasEdgeFromTo(Node(0), Node(1)) || len(g.To(Node(1))) != 0 {
+		t.Error("edge not removed")
+	}
+	if !g.HasEdgeFromTo(Node(1), Node(0)) {
+		t.Error("reverse edge removed")
+	}
+	if !g.Has(Node(0)) || !g.Has(Node(1)) {
+		t.Error("terminal nodes removed")
+	}
+	if g.RemoveEdgeFromTo(0, 1) {
+		t.Error("absent edge reported as removed")
+	}
+	if g.RemoveEdgeFromTo(2, 3) {
+		t.Error("edge between nonexistent nodes reported as removed")
+	}
+	if g.Has(Node(2)) || g.Has(Node(3)) {
+		t.Error("nonexistent nodes added")
+	}
+}
+
+func TestDirectedGraphInOutDegree(t *testing.T) {
+	g := NewDirectedGraph(0, math.Inf(1))
+	for _, e := range []Edge{
+		{F: Node(0), T: Node(1), W: 1},
+		{F: Node(0), T: Node(2), W: 1},
+		{F: Node(1), T: Node(2), W: 1},
+		{F: Node(2), T: Node(0), W: 1},
+	} {
+		g.SetEdge(e)
+	}
+	g.AddNode(Node(3))
+
+	for _, test := range []struct {
+		id      int
+		in, out int
+	}{
+		{id: 0, in: 1, out: 2},
+		{id: 1, in: 1, out: 1},
+		{id: 2, in: 2, out: 1},
+		{id: 3, in: 0, out: 0},
+		{id: 4, in: -1, out: -1},
+	} {
+		if got := g.InDegree(test.id); got != test.in {
+			t.Errorf("unexpected in degree for node %d: got:%d want:%d", test.id, got, test.in)
+		}
+		if got := g.OutDegree(test.id); got != test.out {
+			t.Errorf("unexpected out degree for node %d: got:%d want:%d", test.id, got, test.out)
+		}
+		if test.in < 0 {
+			continue
+		}
+		if got, want := test.in+test.out, g.Degree(Node(test.id)); got != want {
+			t.Errorf("in and out degrees for node %d do not sum to degree: got:%d want:%d", test.id, got, want)
+		}
+	}
+
+	// Self edges are rejected, leaving the degrees unchanged.
+	func() {
+		defer func() { recover() }()
+		g.SetEdge(Edge{F: Node(1), T: Node(1), W: 1})
+	}()
+	if in, out := g.InDegree(1), g.OutDegree(1); in != 1 || out != 1 {
+		t.Errorf("unexpected degrees after rejected self edge: in:%d out:%d", in, out)
+	}
+}
+
+func TestDirectedGraphFromToIter(t *testing.T) {
+	g := NewDirectedGraph(0, math.Inf(1))
+	g.SetEdges(randomEdges(200, 20))
+	g.AddNode(Node(20))
+
+	for _, u := range g.Nodes() {
+		var from, to []graph.Node
+		g.FromIter(u.ID(), func(v graph.Node) bool {
+			from = append(from, v)
+			return true
+		})
+		g.ToIter(u.ID(), func(v graph.Node) bool {
+			to = append(to, v)
+			return true
+		})
+		if !sameNodes(from, g.From(u)) {
+			t.Errorf("unexpected FromIter nodes for %d: got:%v want:%v", u.ID(), from, g.From(u))
+		}
+		if !sameNodes(to, g.To(u)) {
+			t.Errorf("unexpected ToIter nodes for %d: got:%v want:%v", u.ID(), to, g.To(u))
+		}
+	}
+
+	var n int
+	g.FromIter(0, func(graph.Node) bool {
+		n++
+		return n < 2
+	})
+	if n != 2 {
+		t.Errorf("iteration not stopped: visited %d nodes", n)
+	}
+	g.FromIter(21, func(graph.Node) bool {
+		t.Error("unexpected node for nonexistent ID")
+		return true
+	})
+}
+
+// sameNodes returns whether a and b hold the same node IDs in any order.
+func sameNodes(a, b []graph.Node) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	count := make(map[int]int)
+	for _, n := range a {
+		count[n.ID()]++
+	}
+	for _, n := range b {
+		count[n.ID()]--
+	}
+	for _, c := range count {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// denseDirectedGraph returns a complete directed graph on n nodes.
+func denseDirectedGraph(n int) *DirectedGraph {
+	g := NewDirectedGraph(0, math.Inf(1))
+	for u := 0; u < n; u++ {
+		for v := 0; v < n; v++ {
+			if u != v {
+				g.SetEdge(Edge{F: Node(u), T: Node(v), W: 1})
+			}
+		}
+	}
+	return g
+}
+
+func BenchmarkDirectedGraphFrom(b *testing.B) {
+	g := denseDirectedGraph(500)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var sum int
+		for _, v := range g.From(Node(i % 500)) {
+			sum += v.ID()
+		}
+	}
+}
+
+func BenchmarkDirectedGraphFromIter(b *testing.B) {
+	g := denseDirectedGraph(500)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var sum int
+		g.FromIter(i%500, func(v graph.Node) bool {
+			sum += v.ID()
+			return true
+		})
+	}
+}
+
+func TestDirectedGraphTranspose(t *testing.T) {
+	g := NewDirectedGraph(-1, math.Inf(1))
+	g.SetEdges(randomEdges(200, 20))
+	g.SetEdge(Edge{F: Node(3), T: Node(20), W: 2.5})
+	g.AddNode(Node(21))
+
+	tr := g.Transpose()
+	if len(tr.Nodes()) != len(g.Nodes()) {
+		t.Fatalf("unexpected number of nodes: got:%d want:%d", len(tr.Nodes()), len(g.Nodes()))
+	}
+	if tr.Self() != g.Self() || tr.Absent() != g.Absent() {
+		t.Errorf("unexpected self and absent weights: got:%v and %v want:%v and %v", tr.Self(), tr.Absent(), g.Self(), g.Absent())
+	}
+	for _, u := range g.Nodes() {
+		if !tr.Has(u) {
+			t.Errorf("node %d missing from transpose", u.ID())
+		}
+		if !sameNodes(tr.From(u), g.To(u)) {
+			t.Errorf("unexpected From nodes for %d in transpose: got:%v want:%v", u.ID(), tr.From(u), g.To(u))
+		}
+		if !sameNodes(tr.To(u), g.From(u)) {
+			t.Errorf("unexpected To nodes for %d in transpose: got:%v want:%v", u.ID(), tr.To(u), g.From(u))
+		}
+		for _, v := range g.From(u) {
+			e := tr.Edge(v, u)
+			if e.From().ID() != v.ID() || e.To().ID() != u.ID() || e.Weight() != g.Edge(u, v).Weight() {
+				t.Errorf("unexpected transposed edge for %d->%d: %v", u.ID(), v.ID(), e)
+			}
+		}
+	}
+
+	tr.RemoveNode(Node(20))
+	tr.SetEdge(Edge{F: Node(0), T: Node(21), W: 1})
+	if !g.Has(Node(20)) || !g.HasEdgeFromTo(Node(3), Node(20)) {
+		t.Error("removing node from transpose changed original")
+	}
+	if g.HasEdgeFromTo(Node(0), Node(21)) {
+		t.Error("adding edge to transpose changed original")
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import "gonum.org/v1/gonum/graph"
+
+// Equal returns whether a and b are structurally identical. The graphs are
+// equal when both or neither are graph.Directed, they hold the same set of
+// node IDs and they hold the same edges with the same weights, as returned by
+// the edges' Weight method, where NaN weights are considered equal. Edge
+// direction is respected for directed graphs and ignored for undirected
+// graphs. Nodes are compared only by their IDs; any other node attributes
+// are not considered.
+func Equal(a, b graph.Graph) bool {
+	_, aDirected := a.(graph.Directed)
+	_, bDirected := b.(graph.Directed)
+	if aDirected != bDirected {
+		return false
+	}
+
+	nodes := a.Nodes()
+	if len(nodes) != len(b.Nodes()) {
+		return false
+	}
+	for _, u := range nodes {
+		if !b.Has(u) {
+			return false
+		}
+	}
+	for _, u := range nodes {
+		to := a.From(u)
+		if len(to) != len(b.From(u)) {
+			return false
+		}
+		for _, v := range to {
+			eb := b.Edge(u, v)
+			if eb == nil || !isSame(eb.Weight(), a.Edge(u, v).Weight()) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,75 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+func TestEqual(t *testing.T) {
+	edges := []Edge{
+		{F: Node(0), T: Node(1), W: 1},
+		{F: Node(1), T: Node(2), W: 2},
+		{F: Node(2), T: Node(0), W: 3},
+	}
+	directed := func(edges []Edge, nodes ...int) graph.Graph {
+		g := NewDirectedGraph(0, math.Inf(1))
+		for _, id := range nodes {
+			g.AddNode(Node(id))
+		}
+		for _, e := range edges {
+			g.SetEdge(e)
+		}
+		return g
+	}
+	undirected := func(edges []Edge, nodes ...int) graph.Graph {
+		g := NewUndirectedGraph(0, math.Inf(1))
+		for _, id := range nodes {
+			g.AddNode(Node(id))
+		}
+		for _, e := range edges {
+			g.SetEdge(e)
+		}
+		return g
+	}
+	reversed := make([]Edge, len(edges))
+	for i, e := range edges {
+		reversed[i] = Edge{F: e.T, T: e.F, W: e.W}
+	}
+	reweighted := append([]Edge(nil), edges...)
+	reweighted[1].W = 2.5
+	nan := append([]Edge(nil), edges...)
+	nan[1].W = math.NaN()
+
+	for _, test := range []struct {
+		name string
+		a, b graph.Graph
+		want bool
+	}{
+		{name: "directed same", a: directed(edges), b: directed(edges), want: true},
+		{name: "directed different isolated node", a: directed(edges, 3), b: directed(edges, 4), want: false},
+		{name: "directed same isolated node", a: directed(edges, 3), b: directed(edges, 3), want: true},
+		{name: "directed reversed", a: directed(edges), b: directed(reversed), want: false},
+		{name: "directed weight mismatch", a: directed(edges), b: directed(reweighted), want: false},
+		{name: "directed missing edge", a: directed(edges), b: directed(edges[:2], 0), want: false},
+		{name: "undirected reversed", a: undirected(edges), b: undirected(reversed), want: true},
+		{name: "undirected weight mismatch", a: undirected(edges), b: undirected(reweighted), want: false},
+		{name: "directed NaN weight", a: directed(nan), b: directed(nan), want: true},
+		{name: "undirected NaN weight", a: undirected(nan), b: undirected(nan), want: true},
+		{name: "NaN weight mismatch", a: directed(nan), b: directed(edges), want: false},
+		{name: "mixed directedness", a: directed(edges), b: undirected(edges), want: false},
+		{name: "empty", a: directed(nil), b: directed(nil), want: true},
+	} {
+		if got := Equal(test.a, test.b); got != test.want {
+			t.Errorf("unexpected result for %s: got:%t want:%t", test.name, got, test.want)
+		}
+		if got := Equal(test.b, test.a); got != test.want {
+			t.Errorf("unexpected result for reversed arguments for %s: got:%t want:%t", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// gobGraph is the gob encoding of a DirectedGraph or UndirectedGraph.
+type gobGraph struct {
+	Self, Absent float64
+
+	Nodes []int
+	Edges []gobEdge
+
+	// MaxID and Free hold the state of the
+	// node ID allocator.
+	MaxID int
+	Free  []int
+}
+
+// gobEdge is the gob encoding of an edge.
+type gobEdge struct {
+	From, To int
+	Weight   float64
+}
+
+// GobEncode implements the gob.GobEncoder interface. The encoding holds
+// the node IDs, the edges and their weights, the self and absent weights
+// and the state of the node ID allocator, including the IDs freed by node
+// removal. The concrete types of the nodes and edges are not retained.
+func (g *DirectedGraph) GobEncode() ([]byte, error) {
+	return encodeGob(g.self, g.absent, g.Nodes(), g.Edges(), g.nodeIDs)
+}
+
+// GobDecode implements the gob.GobDecoder interface. The decoded graph
+// holds Node and Edge values in place of the nodes and edges of the
+// encoded graph. GobDecode returns an error, leaving g unchanged, if the
+// encoding lists a node ID twice, or holds a self edge or an edge with an
+// endpoint that is not listed in the nodes.
+func (g *DirectedGraph) GobDecode(b []byte) error {
+	enc, err := decodeGob(b)
+	if err != nil {
+		return err
+	}
+	*g = *enc.directed()
+	return nil
+}
+
+// GobEncode implements the gob.GobEncoder interface. The encoding holds
+// the node IDs, the edges and their weights, the self and absent weights
+// and the state of the node ID allocator, including the IDs freed by node
+// removal. The concrete types of the nodes and edges are not retained.
+func (g *UndirectedGraph) GobEncode() ([]byte, error) {
+	return encodeGob(g.self, g.absent, g.Nodes(), g.Edges(), g.nodeIDs)
+}
+
+// GobDecode implements the gob.GobDecoder interface. The decoded graph
+// holds Node and Edge values in place of the nodes and edges of the
+// encoded graph. GobDecode returns an error, leaving g unchanged, if the
+// encoding lists a node ID twice, or holds a self edge or an edge with an
+// endpoint that is not listed in the nodes.
+func (g *UndirectedGraph) GobDecode(b []byte) error {
+	enc, err := decodeGob(b)
+	if err != nil {
+		return err
+	}
+	*g = *enc.undirected()
+	return nil
+}
+
+// encodeGob returns the gob encoding of a graph with the given components.
+func encodeGob(self, absent float64, nodes []graph.Node, edges []graph.Edge, ids idSet) ([]byte, error) {
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(newGobGraph(self, absent, nodes, edges, ids))
+	return buf.Bytes(), err
+}
+
+// newGobGraph returns the encoding of a graph with the given components
+// with nodes and edges sorted by ID.
+func newGobGraph(self, absent float64, nodes []graph.Node, edges []graph.Edge, ids idSet) gobGraph {
+	sort.Sort(ordered.ByID(nodes))
+	enc := gobGraph{
+		Self:   self,
+		Absent: absent,
+		Nodes:  make([]int, len(nodes)),
+		Edges:  make([]gobEdge, len(edges)),
+		MaxID:  ids.maxID,
+	}
+	for i, n := range nodes {
+		enc.Nodes[i] = n.ID()
+	}
+	for i, e := range edges {
+		enc.Edges[i] = gobEdge{From: e.From().ID(), To: e.To().ID(), Weight: e.Weight()}
+	}
+	sort.Sort(byEndpoints(enc.Edges))
+	for id := range ids.free {
+		enc.Free = append(enc.Free, id)
+	}
+	sort.Ints(enc.Free)
+	return enc
+}
+
+// decodeGob decodes and validates a gobGraph from b.
+func decodeGob(b []byte) (gobGraph, error) {
+	var enc gobGraph
+	err := gob.NewDecoder(bytes.NewReader(b)).Decode(&enc)
+	if err != nil {
+		return enc, err
+	}
+	return enc, enc.validate()
+}
+
+// validate returns an error if the encoding does not describe a valid
+// graph, so that building the graph from it cannot panic.
+func (enc gobGraph) validate() error {
+	nodes := make(map[int]bool, len(enc.Nodes))
+	for _, id := range enc.Nodes {
+		if nodes[id] {
+			return fmt.Errorf("simple: duplicate node ID %d in encoding", id)
+		}
+		nodes[id] = true
+	}
+	for _, e := range enc.Edges {
+		if e.From == e.To {
+			return fmt.Errorf("simple: self edge on node %d in encoding", e.From)
+		}
+		if !nodes[e.From] || !nodes[e.To] {
+			return fmt.Errorf("simple: edge from %d to %d has an endpoint missing from encoded nodes", e.From, e.To)
+		}
+	}
+	return nil
+}
+
+// directed returns the DirectedGraph described by the encoding.
+func (enc gobGraph) directed() *DirectedGraph {
+	g := NewDirectedGraph(enc.Self, enc.Absent)
+	for _, id := range enc.Nodes {
+		g.AddNode(Node(id))
+	}
+	for _, e := range enc.Edges {
+		g.SetEdge(Edge{F: Node(e.From), T: Node(e.To), W: e.Weight})
+	}
+	enc.restoreIDs(&g.nodeIDs)
+	return g
+}
+
+// undirected returns the UndirectedGraph described by the encoding.
+func (enc gobGraph) undirected() *UndirectedGraph {
+	g := NewUndirectedGraph(enc.Self, enc.Absent)
+	for _, id := range enc.Nodes {
+		g.AddNode(Node(id))
+	}
+	for _, e := range enc.Edges {
+		g.SetEdge(Edge{F: Node(e.From), T: Node(e.To), W: e.Weight})
+	}
+	enc.restoreIDs(&g.nodeIDs)
+	return g
+}
+
+// restoreIDs sets the node ID allocator state of s from the encoding.
+// The used IDs must already have been added to s. Free IDs that are in
+// use and a maximum ID less than a used ID are ignored, so an inconsistent
+// encoding cannot cause a new node ID to collide with an existing node.
+func (enc gobGraph) restoreIDs(s *idSet) {
+	if enc.MaxID > s.maxID {
+		s.maxID = enc.MaxID
+	}
+	for _, id := range enc.Free {
+		if !s.used.Has(id) {
+			s.free.Add(id)
+		}
+	}
+}
+
+// byEndpoints sorts gob edges by their from and then to node IDs.
+type byEndpoints []gobEdge
+
+func (e byEndpoints) Len() int { return len(e) }
+func (e byEndpoints) Less(i, j int) bool {
+	return e[i].From < e[j].From || (e[i].From == e[j].From && e[i].To < e[j].To)
+}
+func (e byEndpoints) Swap(i, j int) { e[i], e[j] = e[j], e[i] }
@@ -0,0 +1,103 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"bytes"
+	"encoding/gob"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestGobDirectedGraph(t *testing.T) {
+	removed := generateDummyGraph()
+	removed.SetEdge(Edge{F: Node(3), T: Node(4), W: 2.5})
+	removed.RemoveNode(Node(1))
+
+	weighted := NewDirectedGraph(-1, 1e9)
+	weighted.SetEdge(Edge{F: Node(5), T: Node(0), W: math.Inf(1)})
+	weighted.SetEdge(Edge{F: Node(0), T: Node(5), W: -3})
+
+	for _, g := range []*DirectedGraph{
+		NewDirectedGraph(0, math.Inf(1)),
+		generateDummyGraph(),
+		removed,
+		weighted,
+	} {
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(g); err != nil {
+			t.Fatalf("unexpected error encoding graph: %v", err)
+		}
+		var got *DirectedGraph
+		if err := gob.NewDecoder(&buf).Decode(&got); err != nil {
+			t.Fatalf("unexpected error decoding graph: %v", err)
+		}
+		if !reflect.DeepEqual(got, g) {
+			t.Errorf("unexpected decoded graph:\ngot: %+v\nwant:%+v", got, g)
+		}
+		if got.NewNodeID() != g.NewNodeID() {
+			t.Errorf("unexpected new node ID: got:%d want:%d", got.NewNodeID(), g.NewNodeID())
+		}
+	}
+}
+
+func TestGobUndirectedGraph(t *testing.T) {
+	g := NewUndirectedGraph(0, math.Inf(1))
+	for _, e := range []Edge{
+		{F: Node(0), T: Node(1), W: 1},
+		{F: Node(2), T: Node(1), W: 2},
+		{F: Node(3), T: Node(0), W: 0.5},
+	} {
+		g.SetEdge(e)
+	}
+	g.RemoveNode(Node(2))
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(g); err != nil {
+		t.Fatalf("unexpected error encoding graph: %v", err)
+	}
+	var got *UndirectedGraph
+	if err := gob.NewDecoder(&buf).Decode(&got); err != nil {
+		t.Fatalf("unexpected error decoding graph: %v", err)
+	}
+	if !reflect.DeepEqual(got, g) {
+		t.Errorf("unexpected decoded graph:\ngot: %+v\nwant:%+v", got, g)
+	}
+	if id := got.NewNodeID(); id != 2 {
+		t.Errorf("freed node ID not reused: got:%d want:2", id)
+	}
+}
+
+func TestGobDecodeMalformed(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		enc  gobGraph
+	}{
+		{name: "self edge", enc: gobGraph{Nodes: []int{1}, Edges: []gobEdge{{From: 1, To: 1, Weight: 1}}}},
+		{name: "missing endpoint", enc: gobGraph{Nodes: []int{0}, Edges: []gobEdge{{From: 0, To: 1, Weight: 1}}}},
+		{name: "duplicate node", enc: gobGraph{Nodes: []int{0, 0}}},
+	} {
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(test.enc); err != nil {
+			t.Fatalf("unexpected error encoding %s: %v", test.name, err)
+		}
+		b := buf.Bytes()
+
+		dg := NewDirectedGraph(0, math.Inf(1))
+		dg.AddNode(Node(5))
+		if err := dg.GobDecode(b); err == nil {
+			t.Errorf("expected error decoding directed graph with %s", test.name)
+		}
+		ug := NewUndirectedGraph(0, math.Inf(1))
+		ug.AddNode(Node(5))
+		if err := ug.GobDecode(b); err == nil {
+			t.Errorf("expected error decoding undirected graph with %s", test.name)
+		}
+		if !dg.Has(Node(5)) || !ug.Has(Node(5)) {
+			t.Errorf("graph modified by failed decoding of %s", test.name)
+		}
+	}
+}
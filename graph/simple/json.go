@@ -0,0 +1,121 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+)
+
+// jsonGraph is the JSON encoding of a DirectedGraph.
+type jsonGraph struct {
+	Self   jsonFloat  `json:"self"`
+	Absent jsonFloat  `json:"absent"`
+	Nodes  []int      `json:"nodes"`
+	Edges  []jsonEdge `json:"edges"`
+	MaxID  int        `json:"maxID"`
+	Free   []int      `json:"free,omitempty"`
+}
+
+// jsonEdge is the JSON encoding of an edge.
+type jsonEdge struct {
+	From   int       `json:"from"`
+	To     int       `json:"to"`
+	Weight jsonFloat `json:"weight"`
+}
+
+// MarshalJSON implements the json.Marshaler interface. The encoding is an
+// object of the form
+//  {
+//      "self": 0,
+//      "absent": "+Inf",
+//      "nodes": [0, 1, 2],
+//      "edges": [{"from": 0, "to": 1, "weight": 1.5}],
+//      "maxID": 3,
+//      "free": [3]
+//  }
+// holding the self and absent weights, the node IDs in ascending order, the
+// edges and their weights sorted by their from and then to node IDs, and the
+// state of the node ID allocator. The free field lists the IDs freed by node
+// removal and is omitted when empty. Weights are JSON numbers, except that
+// infinite and NaN weights are the strings "+Inf", "-Inf" and "NaN". The
+// concrete types of the nodes and edges are not retained.
+func (g *DirectedGraph) MarshalJSON() ([]byte, error) {
+	enc := newGobGraph(g.self, g.absent, g.Nodes(), g.Edges(), g.nodeIDs)
+	j := jsonGraph{
+		Self:   jsonFloat(enc.Self),
+		Absent: jsonFloat(enc.Absent),
+		Nodes:  enc.Nodes,
+		Edges:  make([]jsonEdge, len(enc.Edges)),
+		MaxID:  enc.MaxID,
+		Free:   enc.Free,
+	}
+	for i, e := range enc.Edges {
+		j.Edges[i] = jsonEdge{From: e.From, To: e.To, Weight: jsonFloat(e.Weight)}
+	}
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for the encoding
+// described by MarshalJSON. The decoded graph holds Node and Edge values in
+// place of the nodes and edges of the encoded graph. UnmarshalJSON returns an
+// error, leaving g unchanged, if a node ID is listed twice, or if an edge is a
+// self edge or has an endpoint that is not listed in the nodes.
+func (g *DirectedGraph) UnmarshalJSON(b []byte) error {
+	var j jsonGraph
+	err := json.Unmarshal(b, &j)
+	if err != nil {
+		return err
+	}
+	enc := gobGraph{
+		Self:   float64(j.Self),
+		Absent: float64(j.Absent),
+		Nodes:  j.Nodes,
+		Edges:  make([]gobEdge, len(j.Edges)),
+		MaxID:  j.MaxID,
+		Free:   j.Free,
+	}
+	for i, e := range j.Edges {
+		enc.Edges[i] = gobEdge{From: e.From, To: e.To, Weight: float64(e.Weight)}
+	}
+	err = enc.validate()
+	if err != nil {
+		return err
+	}
+	*g = *enc.directed()
+	return nil
+}
+
+// jsonFloat is a float64 that encodes infinite and NaN values as JSON
+// strings.
+type jsonFloat float64
+
+// MarshalJSON implements the json.Marshaler interface.
+func (f jsonFloat) MarshalJSON() ([]byte, error) {
+	v := float64(f)
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		return []byte(strconv.Quote(strconv.FormatFloat(v, 'g', -1, 64))), nil
+	}
+	return []byte(strconv.FormatFloat(v, 'g', -1, 64)), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (f *jsonFloat) UnmarshalJSON(b []byte) error {
+	s := string(b)
+	if len(s) != 0 && s[0] == '"' {
+		var err error
+		s, err = strconv.Unquote(s)
+		if err != nil {
+			return err
+		}
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return err
+	}
+	*f = jsonFloat(v)
+	return nil
+}
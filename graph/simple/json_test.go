@@ -0,0 +1,99 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestJSONDirectedGraph(t *testing.T) {
+	removed := generateDummyGraph()
+	removed.SetEdge(Edge{F: Node(3), T: Node(4), W: 2.5})
+	removed.RemoveNode(Node(1))
+
+	weighted := NewDirectedGraph(-1, math.Inf(1))
+	weighted.SetEdge(Edge{F: Node(5), T: Node(0), W: math.Inf(-1)})
+	weighted.SetEdge(Edge{F: Node(0), T: Node(5), W: -3e-20})
+	weighted.SetEdge(Edge{F: Node(0), T: Node(2), W: 0.1})
+
+	for _, g := range []*DirectedGraph{
+		NewDirectedGraph(0, math.Inf(1)),
+		generateDummyGraph(),
+		removed,
+		weighted,
+	} {
+		b, err := json.Marshal(g)
+		if err != nil {
+			t.Fatalf("unexpected error encoding graph: %v", err)
+		}
+		var got *DirectedGraph
+		if err := json.Unmarshal(b, &got); err != nil {
+			t.Fatalf("unexpected error decoding graph: %v", err)
+		}
+		if !Equal(got, g) {
+			t.Errorf("decoded graph not equal to original:\ngot: %+v\nwant:%+v", got, g)
+		}
+		if !reflect.DeepEqual(got, g) {
+			t.Errorf("unexpected decoded graph:\ngot: %+v\nwant:%+v", got, g)
+		}
+		if got.NewNodeID() != g.NewNodeID() {
+			t.Errorf("unexpected new node ID: got:%d want:%d", got.NewNodeID(), g.NewNodeID())
+		}
+		if got.Self() != g.Self() || got.Absent() != g.Absent() {
+			t.Errorf("unexpected self and absent weights: got:%v and %v want:%v and %v",
+				got.Self(), got.Absent(), g.Self(), g.Absent())
+		}
+	}
+}
+
+func TestJSONDirectedGraphSchema(t *testing.T) {
+	g := NewDirectedGraph(0, math.Inf(1))
+	g.SetEdge(Edge{F: Node(1), T: Node(0), W: 1.5})
+	g.SetEdge(Edge{F: Node(0), T: Node(2), W: math.NaN()})
+	g.AddNode(Node(4))
+	g.RemoveNode(Node(4))
+
+	b, err := json.Marshal(g)
+	if err != nil {
+		t.Fatalf("unexpected error encoding graph: %v", err)
+	}
+	want := `{"self":0,"absent":"+Inf","nodes":[0,1,2],"edges":[{"from":0,"to":2,"weight":"NaN"},{"from":1,"to":0,"weight":1.5}],"maxID":4,"free":[4]}`
+	if string(b) != want {
+		t.Errorf("unexpected encoding:\ngot: %s\nwant:%s", b, want)
+	}
+
+	b, err = json.Marshal(NewDirectedGraph(0, 1))
+	if err != nil {
+		t.Fatalf("unexpected error encoding empty graph: %v", err)
+	}
+	want = `{"self":0,"absent":1,"nodes":[],"edges":[],"maxID":-1}`
+	if string(b) != want {
+		t.Errorf("unexpected encoding of empty graph:\ngot: %s\nwant:%s", b, want)
+	}
+}
+
+func TestJSONDirectedGraphMalformed(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		json string
+	}{
+		{name: "self edge", json: `{"self":0,"absent":"+Inf","nodes":[1],"edges":[{"from":1,"to":1,"weight":1}],"maxID":1}`},
+		{name: "missing endpoint", json: `{"self":0,"absent":"+Inf","nodes":[0],"edges":[{"from":0,"to":1,"weight":1}],"maxID":1}`},
+		{name: "duplicate node", json: `{"self":0,"absent":"+Inf","nodes":[0,0],"edges":[],"maxID":0}`},
+		{name: "bad weight", json: `{"self":0,"absent":"+Inf","nodes":[0,1],"edges":[{"from":0,"to":1,"weight":"x"}],"maxID":1}`},
+	} {
+		g := NewDirectedGraph(0, math.Inf(1))
+		g.AddNode(Node(5))
+		if err := json.Unmarshal([]byte(test.json), g); err == nil {
+			t.Errorf("expected error decoding graph with %s", test.name)
+		}
+		if !g.Has(Node(5)) {
+			t.Errorf("graph modified by failed decoding of %s", test.name)
+		}
+	}
+}
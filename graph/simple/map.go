@@ -0,0 +1,41 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import "gonum.org/v1/gonum/graph"
+
+// MapWeights returns a new graph with the nodes and edges of g and each edge
+// weight w replaced by f(w). If g is a graph.Directed the returned graph is
+// a *DirectedGraph, otherwise it is an *UndirectedGraph. The returned graph
+// has the self and absent weights of g if it has Self and Absent methods, or
+// zero and +Inf otherwise, and edges carrying the absent weight are copied
+// without applying f.
+//
+// If g is a graph.Weighter the weights are obtained from its Weight method,
+// otherwise they are obtained from the edges' Weight method.
+func MapWeights(g graph.Graph, f func(w float64) float64) graph.Graph {
+	dst := newGraphLike(g)
+	absent := dst.(selfAbsenter).Absent()
+	_, directed := g.(graph.Directed)
+
+	weight := edgeWeightFunc(g)
+	nodes := g.Nodes()
+	for _, n := range nodes {
+		dst.AddNode(n)
+	}
+	for _, u := range nodes {
+		for _, v := range g.From(u) {
+			if !directed && dst.HasEdgeBetween(u, v) {
+				continue
+			}
+			w := weight(u, v)
+			if !isSame(w, absent) {
+				w = f(w)
+			}
+			dst.SetEdge(Edge{F: u, T: v, W: w})
+		}
+	}
+	return dst
+}
@@ -0,0 +1,71 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+func TestMapWeights(t *testing.T) {
+	double := func(w float64) float64 { return 2 * w }
+
+	directed := generateDummyGraph()
+	directed.SetEdge(Edge{F: Node(3), T: Node(1), W: 2.5})
+	undirected := NewUndirectedGraph(0, -1)
+	for _, e := range []Edge{
+		{F: Node(0), T: Node(1), W: 1},
+		{F: Node(1), T: Node(2), W: 3},
+		{F: Node(2), T: Node(0), W: -1},
+	} {
+		undirected.SetEdge(e)
+	}
+	undirected.AddNode(Node(4))
+
+	for _, g := range []interface {
+		graph.Graph
+		graph.Weighter
+		Absent() float64
+	}{directed, undirected} {
+		got := MapWeights(g, double)
+		_, wantDirected := g.(graph.Directed)
+		if _, gotDirected := got.(graph.Directed); gotDirected != wantDirected {
+			t.Errorf("unexpected directedness: got:%t want:%t", gotDirected, wantDirected)
+		}
+		if len(got.Nodes()) != len(g.Nodes()) {
+			t.Errorf("unexpected number of nodes: got:%d want:%d", len(got.Nodes()), len(g.Nodes()))
+		}
+		gw := got.(graph.Weighter)
+		for _, u := range g.Nodes() {
+			if len(got.From(u)) != len(g.From(u)) {
+				t.Errorf("unexpected number of neighbors of %d: got:%d want:%d", u.ID(), len(got.From(u)), len(g.From(u)))
+			}
+			for _, v := range g.From(u) {
+				want, _ := g.Weight(u, v)
+				if want != g.Absent() {
+					want *= 2
+				}
+				if w, ok := gw.Weight(u, v); !ok || w != want {
+					t.Errorf("unexpected weight for %d-%d: got:%v want:%v", u.ID(), v.ID(), w, want)
+				}
+			}
+		}
+		if a := got.(interface {
+			Absent() float64
+		}).Absent(); a != g.Absent() {
+			t.Errorf("unexpected absent weight: got:%v want:%v", a, g.Absent())
+		}
+	}
+
+	// The absent edge weight is not mapped.
+	got := MapWeights(undirected, double).(*UndirectedGraph)
+	if w, _ := got.Weight(Node(2), Node(0)); w != -1 {
+		t.Errorf("unexpected weight for absent sentinel edge: got:%v want:-1", w)
+	}
+	if w, ok := got.Weight(Node(4), Node(0)); ok || w != -1 {
+		t.Errorf("unexpected weight for non-edge: got:%v,%t want:-1,false", w, ok)
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"fmt"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// DirectedMultigraph implements a directed graph that may hold more than one
+// edge from one node to another.
+//
+// DirectedMultigraph implements graph.Directed by exposing the adjacency of
+// its nodes: a node v is in From(u) if there is at least one edge from u to v.
+// Where there are parallel edges, Edge and Weight use the edge with the
+// minimum weight, so shortest path algorithms using the graph interfaces find
+// paths over the cheapest of the parallel edges. All the parallel edges are
+// available from the Edges method.
+type DirectedMultigraph struct {
+	nodes map[int]graph.Node
+	from  map[int]map[int][]graph.Edge
+	to    map[int]map[int][]graph.Edge
+
+	self, absent float64
+
+	nodeIDs idSet
+}
+
+// NewDirectedMultigraph returns a DirectedMultigraph with the specified self
+// and absent edge weight values.
+func NewDirectedMultigraph(self, absent float64) *DirectedMultigraph {
+	return &DirectedMultigraph{
+		nodes: make(map[int]graph.Node),
+		from:  make(map[int]map[int][]graph.Edge),
+		to:    make(map[int]map[int][]graph.Edge),
+
+		self:   self,
+		absent: absent,
+
+		nodeIDs: newIDSet(),
+	}
+}
+
+// NewNodeID returns a new unique ID for a node to be added to g. The returned ID does
+// not become a valid ID in g until it is added to g.
+func (g *DirectedMultigraph) NewNodeID() int {
+	if len(g.nodes) == 0 {
+		return 0
+	}
+	if len(g.nodes) == maxInt {
+		panic("simple: cannot allocate node: no slot")
+	}
+	return g.nodeIDs.newID()
+}
+
+// AddNode adds n to the graph. It panics if the added node ID matches an existing node ID.
+func (g *DirectedMultigraph) AddNode(n graph.Node) {
+	if _, exists := g.nodes[n.ID()]; exists {
+		panic(fmt.Sprintf("simple: node ID collision: %d", n.ID()))
+	}
+	g.nodes[n.ID()] = n
+	g.from[n.ID()] = make(map[int][]graph.Edge)
+	g.to[n.ID()] = make(map[int][]graph.Edge)
+	g.nodeIDs.use(n.ID())
+}
+
+// RemoveNode removes n from the graph, as well as any edges attached to it. If the node
+// is not in the graph it is a no-op.
+func (g *DirectedMultigraph) RemoveNode(n graph.Node) {
+	if _, ok := g.nodes[n.ID()]; !ok {
+		return
+	}
+	delete(g.nodes, n.ID())
+
+	for from := range g.from[n.ID()] {
+		delete(g.to[from], n.ID())
+	}
+	delete(g.from, n.ID())
+
+	for to := range g.to[n.ID()] {
+		delete(g.from[to], n.ID())
+	}
+	delete(g.to, n.ID())
+
+	g.nodeIDs.release(n.ID())
+}
+
+// SetEdge adds e, an edge from one node to another, to the edges already joining
+// the nodes. If the nodes do not exist, they are added. It will panic if the IDs
+// of the e.From and e.To are equal.
+func (g *DirectedMultigraph) SetEdge(e graph.Edge) {
+	var (
+		from = e.From()
+		fid  = from.ID()
+		to   = e.To()
+		tid  = to.ID()
+	)
+
+	if fid == tid {
+		panic("simple: adding self edge")
+	}
+
+	if !g.Has(from) {
+		g.AddNode(from)
+	}
+	if !g.Has(to) {
+		g.AddNode(to)
+	}
+
+	g.from[fid][tid] = append(g.from[fid][tid], e)
+	g.to[tid][fid] = append(g.to[tid][fid], e)
+}
+
+// RemoveEdges removes all the edges from the node with ID uid to the node with
+// ID vid, leaving the terminal nodes, and returns the number of edges removed.
+func (g *DirectedMultigraph) RemoveEdges(uid, vid int) int {
+	edges, ok := g.from[uid][vid]
+	if !ok {
+		return 0
+	}
+	delete(g.from[uid], vid)
+	delete(g.to[vid], uid)
+	return len(edges)
+}
+
+// Node returns the node in the graph with the given ID.
+func (g *DirectedMultigraph) Node(id int) graph.Node {
+	return g.nodes[id]
+}
+
+// Has returns whether the node exists within the graph.
+func (g *DirectedMultigraph) Has(n graph.Node) bool {
+	_, ok := g.nodes[n.ID()]
+	return ok
+}
+
+// Nodes returns all the nodes in the graph.
+func (g *DirectedMultigraph) Nodes() []graph.Node {
+	nodes := make([]graph.Node, 0, len(g.nodes))
+	for _, n := range g.nodes {
+		nodes = append(nodes, n)
+	}
+	return nodes
+}
+
+// Edges returns all the edges from the node with ID uid to the node with ID
+// vid in the order they were set.
+func (g *DirectedMultigraph) Edges(uid, vid int) []graph.Edge {
+	edges := g.from[uid][vid]
+	if len(edges) == 0 {
+		return nil
+	}
+	return append([]graph.Edge(nil), edges...)
+}
+
+// From returns all nodes in g that can be reached directly from n.
+func (g *DirectedMultigraph) From(n graph.Node) []graph.Node {
+	if _, ok := g.from[n.ID()]; !ok {
+		return nil
+	}
+
+	from := make([]graph.Node, 0, len(g.from[n.ID()]))
+	for id := range g.from[n.ID()] {
+		from = append(from, g.nodes[id])
+	}
+	return from
+}
+
+// To returns all nodes in g that can reach directly to n.
+func (g *DirectedMultigraph) To(n graph.Node) []graph.Node {
+	if _, ok := g.to[n.ID()]; !ok {
+		return nil
+	}
+
+	to := make([]graph.Node, 0, len(g.to[n.ID()]))
+	for id := range g.to[n.ID()] {
+		to = append(to, g.nodes[id])
+	}
+	return to
+}
+
+// HasEdgeBetween returns whether an edge exists between nodes x and y without
+// considering direction.
+func (g *DirectedMultigraph) HasEdgeBetween(x, y graph.Node) bool {
+	if _, ok := g.from[x.ID()][y.ID()]; ok {
+		return true
+	}
+	_, ok := g.from[y.ID()][x.ID()]
+	return ok
+}
+
+// HasEdgeFromTo returns whether an edge exists in the graph from u to v.
+func (g *DirectedMultigraph) HasEdgeFromTo(u, v graph.Node) bool {
+	_, ok := g.from[u.ID()][v.ID()]
+	return ok
+}
+
+// Edge returns the edge with the minimum weight from u to v if such an edge
+// exists and nil otherwise. Where parallel edges share the minimum weight, the
+// first to be set is returned. The node v must be directly reachable from u as
+// defined by the From method.
+func (g *DirectedMultigraph) Edge(u, v graph.Node) graph.Edge {
+	edges := g.from[u.ID()][v.ID()]
+	if len(edges) == 0 {
+		return nil
+	}
+	min := edges[0]
+	for _, e := range edges[1:] {
+		if e.Weight() < min.Weight() {
+			min = e
+		}
+	}
+	return min
+}
+
+// Weight returns the minimum weight of the edges from x to y if Edge(x, y)
+// returns a non-nil Edge. If x and y are the same node or there is no joining
+// edge between the two nodes the weight value returned is either the graph's
+// absent or self value. Weight returns true if an edge exists between x and y
+// or if x and y have the same ID, false otherwise.
+func (g *DirectedMultigraph) Weight(x, y graph.Node) (w float64, ok bool) {
+	if x.ID() == y.ID() {
+		return g.self, true
+	}
+	if e := g.Edge(x, y); e != nil {
+		return e.Weight(), true
+	}
+	return g.absent, false
+}
+
+// Self returns the weight returned by Weight for self edges.
+func (g *DirectedMultigraph) Self() float64 {
+	return g.self
+}
+
+// Absent returns the weight returned by Weight for absent edges.
+func (g *DirectedMultigraph) Absent() float64 {
+	return g.absent
+}
@@ -0,0 +1,91 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+var (
+	_ graph.DirectedBuilder = (*DirectedMultigraph)(nil)
+	_ graph.NodeRemover     = (*DirectedMultigraph)(nil)
+	_ graph.Weighter        = (*DirectedMultigraph)(nil)
+)
+
+func TestDirectedMultigraph(t *testing.T) {
+	g := NewDirectedMultigraph(0, math.Inf(1))
+	parallel := []graph.Edge{
+		Edge{F: Node(0), T: Node(1), W: 3},
+		Edge{F: Node(0), T: Node(1), W: 1},
+		Edge{F: Node(0), T: Node(1), W: 2},
+		Edge{F: Node(0), T: Node(1), W: 1},
+	}
+	for _, e := range parallel {
+		g.SetEdge(e)
+	}
+	g.SetEdge(Edge{F: Node(1), T: Node(0), W: 5})
+	g.SetEdge(Edge{F: Node(1), T: Node(2), W: 4})
+
+	if got := g.Edges(0, 1); !reflect.DeepEqual(got, parallel) {
+		t.Errorf("unexpected parallel edges: got:%v want:%v", got, parallel)
+	}
+	if got := g.Edges(2, 1); got != nil {
+		t.Errorf("unexpected edges for absent edge: got:%v", got)
+	}
+	if got := g.Edge(Node(0), Node(1)); got != parallel[1] {
+		t.Errorf("unexpected minimum weight edge: got:%v want:%v", got, parallel[1])
+	}
+	if w, ok := g.Weight(Node(0), Node(1)); !ok || w != 1 {
+		t.Errorf("unexpected weight: got:%v ok:%t want:1 ok:true", w, ok)
+	}
+	if w, ok := g.Weight(Node(2), Node(1)); ok || !math.IsInf(w, 1) {
+		t.Errorf("unexpected weight for absent edge: got:%v ok:%t want:+Inf ok:false", w, ok)
+	}
+	if w, ok := g.Weight(Node(2), Node(2)); !ok || w != 0 {
+		t.Errorf("unexpected self weight: got:%v ok:%t want:0 ok:true", w, ok)
+	}
+
+	// Parallel edges are seen as a single neighbor.
+	if from := g.From(Node(0)); len(from) != 1 || from[0].ID() != 1 {
+		t.Errorf("unexpected From nodes: got:%v want:[1]", from)
+	}
+	if to := g.To(Node(1)); len(to) != 1 || to[0].ID() != 0 {
+		t.Errorf("unexpected To nodes: got:%v want:[0]", to)
+	}
+	if !g.HasEdgeFromTo(Node(1), Node(2)) || g.HasEdgeFromTo(Node(2), Node(1)) || !g.HasEdgeBetween(Node(2), Node(1)) {
+		t.Error("unexpected edge existence")
+	}
+
+	// Modifying the returned edges does not change the graph.
+	g.Edges(0, 1)[0] = nil
+	if g.Edges(0, 1)[0] == nil {
+		t.Error("graph modified through returned edges")
+	}
+
+	if n := g.RemoveEdges(0, 1); n != len(parallel) {
+		t.Errorf("unexpected number of removed edges: got:%d want:%d", n, len(parallel))
+	}
+	if g.HasEdgeFromTo(Node(0), Node(1)) || !g.HasEdgeFromTo(Node(1), Node(0)) {
+		t.Error("unexpected edges after removal")
+	}
+
+	g.RemoveNode(Node(1))
+	if len(g.Nodes()) != 2 || g.HasEdgeBetween(Node(0), Node(1)) || len(g.From(Node(0))) != 0 {
+		t.Error("unexpected graph after node removal")
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected panic for self edge")
+			}
+		}()
+		g.SetEdge(Edge{F: Node(0), T: Node(0), W: 1})
+	}()
+}
@@ -0,0 +1,42 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// TwoHopNeighbors returns the IDs of the nodes in g reachable from the node
+// with the given ID in exactly two hops, excluding the node itself and its
+// direct neighbors, in ascending order. Hops follow the From method, so for
+// directed graphs the two-hop neighbors are the out-neighbors of the
+// out-neighbors of the node. If the node is not in g, TwoHopNeighbors
+// returns nil.
+func TwoHopNeighbors(g graph.Graph, id int) []int {
+	n := Node(id)
+	if !g.Has(n) {
+		return nil
+	}
+	direct := make(map[int]bool)
+	for _, u := range g.From(n) {
+		direct[u.ID()] = true
+	}
+	seen := make(map[int]bool)
+	var ids []int
+	for _, u := range g.From(n) {
+		for _, v := range g.From(u) {
+			vid := v.ID()
+			if vid == id || direct[vid] || seen[vid] {
+				continue
+			}
+			seen[vid] = true
+			ids = append(ids, vid)
+		}
+	}
+	sort.Ints(ids)
+	return ids
+}
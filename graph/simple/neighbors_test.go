@@ -0,0 +1,49 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestTwoHopNeighbors(t *testing.T) {
+	// 0 is joined to 1 and 2, and 2 to 1, so 1 is a direct neighbor
+	// reachable in two hops and must be excluded.
+	ug := NewUndirectedGraph(0, math.Inf(1))
+	for _, e := range []Edge{
+		{F: Node(0), T: Node(1)},
+		{F: Node(0), T: Node(2)},
+		{F: Node(1), T: Node(2)},
+		{F: Node(1), T: Node(3)},
+		{F: Node(2), T: Node(4)},
+		{F: Node(4), T: Node(5)},
+		{F: Node(3), T: Node(4)},
+	} {
+		ug.SetEdge(e)
+	}
+	if got, want := TwoHopNeighbors(ug, 0), []int{3, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected undirected two-hop neighbors: got:%v want:%v", got, want)
+	}
+
+	// In the directed graph 4 is reached only against edge direction.
+	dg := NewDirectedGraph(0, math.Inf(1))
+	for _, e := range []Edge{
+		{F: Node(0), T: Node(1)},
+		{F: Node(1), T: Node(0)},
+		{F: Node(1), T: Node(3)},
+		{F: Node(0), T: Node(2)},
+		{F: Node(4), T: Node(2)},
+	} {
+		dg.SetEdge(e)
+	}
+	if got, want := TwoHopNeighbors(dg, 0), []int{3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected directed two-hop neighbors: got:%v want:%v", got, want)
+	}
+	if got := TwoHopNeighbors(dg, 10); got != nil {
+		t.Errorf("unexpected two-hop neighbors for absent node: got:%v want:<nil>", got)
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// selfAbsenter is a graph with self and absent edge weights.
+type selfAbsenter interface {
+	Self() float64
+	Absent() float64
+}
+
+// graphBuilder is a graph that can have nodes and edges added.
+type graphBuilder interface {
+	graph.Graph
+	graph.Builder
+}
+
+// newGraphLike returns an empty directed or undirected graph matching the
+// directedness of g. If g has Self and Absent methods their values are used
+// for the new graph, otherwise the self and absent weights are 0 and +Inf.
+func newGraphLike(g graph.Graph) graphBuilder {
+	self, absent := 0.0, math.Inf(1)
+	if sa, ok := g.(selfAbsenter); ok {
+		self, absent = sa.Self(), sa.Absent()
+	}
+	if _, ok := g.(graph.Directed); ok {
+		return NewDirectedGraph(self, absent)
+	}
+	return NewUndirectedGraph(self, absent)
+}
+
+// Subgraph returns the subgraph of g induced by the nodes with the given
+// IDs. The returned graph is a *DirectedGraph if g is a graph.Directed and
+// an *UndirectedGraph otherwise, and holds the nodes of g with IDs in ids
+// and every edge of g joining two of those nodes. Node IDs, nodes and edges
+// are taken from g without renumbering, so edge weights and directions are
+// preserved. IDs that are not in g are ignored.
+func Subgraph(g graph.Graph, ids []int) graph.Graph {
+	in := make(map[int]graph.Node, len(ids))
+	for _, n := range g.Nodes() {
+		in[n.ID()] = n
+	}
+	nodes := make(map[int]graph.Node, len(ids))
+	for _, id := range ids {
+		if n, ok := in[id]; ok {
+			nodes[id] = n
+		}
+	}
+
+	dst := newGraphLike(g)
+	for _, u := range nodes {
+		dst.AddNode(u)
+	}
+	for _, u := range nodes {
+		for _, v := range g.From(u) {
+			if _, ok := nodes[v.ID()]; ok {
+				dst.SetEdge(g.Edge(u, v))
+			}
+		}
+	}
+	return dst
+}
+
+// Union returns the union of a and b. The returned graph is a *DirectedGraph
+// if a and b are graph.Directed and an *UndirectedGraph otherwise, and holds
+// the nodes of both graphs and the edges of both graphs. Where a and b both
+// hold a node ID, the node of a is used. Union returns an error if only one
+// of a and b is a graph.Directed, or if a and b both hold an edge joining the
+// same nodes but with different weights, as returned by the edges' Weight
+// method, where NaN weights are considered equal. The self and absent
+// weights of the returned graph are taken from a if it has Self and Absent
+// methods.
+func Union(a, b graph.Graph) (graph.Graph, error) {
+	_, aDirected := a.(graph.Directed)
+	_, bDirected := b.(graph.Directed)
+	if aDirected != bDirected {
+		return nil, errors.New("simple: mixed directed and undirected graphs")
+	}
+
+	dst := newGraphLike(a)
+	for _, n := range a.Nodes() {
+		dst.AddNode(n)
+	}
+	for _, n := range b.Nodes() {
+		if !dst.Has(n) {
+			dst.AddNode(n)
+		}
+	}
+	for _, u := range a.Nodes() {
+		for _, v := range a.From(u) {
+			dst.SetEdge(a.Edge(u, v))
+		}
+	}
+	for _, u := range b.Nodes() {
+		for _, v := range b.From(u) {
+			e := b.Edge(u, v)
+			if ea := a.Edge(u, v); ea != nil {
+				if !isSame(ea.Weight(), e.Weight()) {
+					return nil, fmt.Errorf("simple: conflicting weights for edge from %d to %d: %v != %v",
+						u.ID(), v.ID(), ea.Weight(), e.Weight())
+				}
+				continue
+			}
+			dst.SetEdge(e)
+		}
+	}
+	return dst, nil
+}
+
+// Intersection returns the intersection of a and b. The returned graph is a
+// *DirectedGraph if a and b are graph.Directed and an *UndirectedGraph
+// otherwise, and holds the nodes of a with IDs also in b, and the edges of a
+// joining nodes that are also joined by an edge in b. Where the edges of a
+// and b differ in weight, the edge of a and so its weight is used. The self
+// and absent weights of the returned graph are taken from a if it has Self
+// and Absent methods. Intersection will panic if only one of a and b is a
+// graph.Directed.
+func Intersection(a, b graph.Graph) graph.Graph {
+	_, aDirected := a.(graph.Directed)
+	_, bDirected := b.(graph.Directed)
+	if aDirected != bDirected {
+		panic("simple: mixed directed and undirected graphs")
+	}
+
+	dst := newGraphLike(a)
+	nodes := a.Nodes()
+	for _, n := range nodes {
+		if b.Has(n) {
+			dst.AddNode(n)
+		}
+	}
+	for _, u := range nodes {
+		if !dst.Has(u) {
+			continue
+		}
+		for _, v := range a.From(u) {
+			if b.Edge(u, v) != nil {
+				dst.SetEdge(a.Edge(u, v))
+			}
+		}
+	}
+	return dst
+}
+
+// Complement returns the complement of g. The returned graph is a
+// *DirectedGraph if g is a graph.Directed and an *UndirectedGraph otherwise,
+// and holds the nodes of g and an edge joining each pair of distinct nodes
+// that are not adjacent in g. For a directed graph the pairs are ordered, so
+// the complement holds an edge from u to v exactly when g does not. The
+// complement holds no self edges. The self and absent weights of the returned
+// graph are taken from g if it has Self and Absent methods, and the weight of
+// each edge of the complement is w. Complement will panic if w is the absent
+// weight of the returned graph, since the edges would then be absent.
+func Complement(g graph.Graph, w float64) graph.Graph {
+	dst := newGraphLike(g)
+	if isSame(w, dst.(selfAbsenter).Absent()) {
+		panic("simple: complement edge weight is the absent weight")
+	}
+
+	nodes := g.Nodes()
+	for _, n := range nodes {
+		dst.AddNode(n)
+	}
+	_, directed := g.(graph.Directed)
+	for i, u := range nodes {
+		for j, v := range nodes {
+			if i == j || (!directed && j < i) {
+				continue
+			}
+			if g.Edge(u, v) == nil {
+				dst.SetEdge(Edge{F: u, T: v, W: w})
+			}
+		}
+	}
+	return dst
+}
@@ -0,0 +1,302 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+func TestSubgraph(t *testing.T) {
+	dg := NewDirectedGraph(0, math.Inf(1))
+	ug := NewUndirectedGraph(0, math.Inf(1))
+	for _, e := range []Edge{
+		{F: Node(0), T: Node(1), W: 1},
+		{F: Node(1), T: Node(2), W: 2},
+		{F: Node(2), T: Node(4), W: 3},
+		{F: Node(4), T: Node(1), W: 4},
+		{F: Node(2), T: Node(3), W: 5},
+		{F: Node(3), T: Node(5), W: 6},
+		{F: Node(5), T: Node(4), W: 7},
+	} {
+		dg.SetEdge(e)
+		ug.SetEdge(e)
+	}
+
+	wantDirected := NewDirectedGraph(0, math.Inf(1))
+	wantUndirected := NewUndirectedGraph(0, math.Inf(1))
+	for _, e := range []Edge{
+		{F: Node(1), T: Node(2), W: 2},
+		{F: Node(2), T: Node(4), W: 3},
+		{F: Node(4), T: Node(1), W: 4},
+	} {
+		wantDirected.SetEdge(e)
+		wantUndirected.SetEdge(e)
+	}
+
+	for _, test := range []struct {
+		name string
+		g    graph.Graph
+		want graph.Graph
+	}{
+		{name: "directed", g: dg, want: wantDirected},
+		{name: "undirected", g: ug, want: wantUndirected},
+	} {
+		// ID 6 is not in the graph and is ignored.
+		got := Subgraph(test.g, []int{4, 1, 2, 6})
+		if !Equal(got, test.want) {
+			t.Errorf("unexpected %s subgraph: got edges:%v want edges:%v",
+				test.name, got.(edgeLister).Edges(), test.want.(edgeLister).Edges())
+		}
+	}
+	if got := Subgraph(dg, []int{4, 5}); !got.(graph.Directed).HasEdgeFromTo(Node(5), Node(4)) || got.(graph.Directed).HasEdgeFromTo(Node(4), Node(5)) {
+		t.Error("subgraph edge direction not preserved")
+	}
+	if len(dg.Nodes()) != 6 || len(dg.Edges()) != 7 {
+		t.Error("source graph modified")
+	}
+
+	got := Subgraph(dg, []int{0, 3})
+	if len(got.Nodes()) != 2 || len(got.(edgeLister).Edges()) != 0 {
+		t.Errorf("unexpected subgraph of unconnected nodes: nodes:%v edges:%v", got.Nodes(), got.(edgeLister).Edges())
+	}
+}
+
+type edgeLister interface {
+	Edges() []graph.Edge
+}
+
+func TestUnion(t *testing.T) {
+	a := NewDirectedGraph(0, math.Inf(1))
+	for _, e := range []Edge{
+		{F: Node(0), T: Node(1), W: 1},
+		{F: Node(1), T: Node(2), W: 2},
+	} {
+		a.SetEdge(e)
+	}
+	a.AddNode(Node(5))
+	b := NewDirectedGraph(0, math.Inf(1))
+	for _, e := range []Edge{
+		{F: Node(1), T: Node(2), W: 2},
+		{F: Node(2), T: Node(1), W: 3},
+		{F: Node(3), T: Node(4), W: 4},
+	} {
+		b.SetEdge(e)
+	}
+
+	want := NewDirectedGraph(0, math.Inf(1))
+	for _, e := range []Edge{
+		{F: Node(0), T: Node(1), W: 1},
+		{F: Node(1), T: Node(2), W: 2},
+		{F: Node(2), T: Node(1), W: 3},
+		{F: Node(3), T: Node(4), W: 4},
+	} {
+		want.SetEdge(e)
+	}
+	want.AddNode(Node(5))
+
+	got, err := Union(a, b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !Equal(got, want) {
+		t.Errorf("unexpected union: got edges:%v want edges:%v", got.(edgeLister).Edges(), want.Edges())
+	}
+	if len(a.Edges()) != 2 || len(b.Edges()) != 3 {
+		t.Error("input graph modified")
+	}
+
+	// Undirected edges conflict regardless of their orientation.
+	ua := NewUndirectedGraph(0, math.Inf(1))
+	ua.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+	ub := NewUndirectedGraph(0, math.Inf(1))
+	ub.SetEdge(Edge{F: Node(1), T: Node(0), W: 2})
+	if _, err := Union(ua, ub); err == nil {
+		t.Error("expected error for conflicting edge weights")
+	}
+	ub.SetEdge(Edge{F: Node(1), T: Node(0), W: 1})
+	got, err = Union(ua, ub)
+	if err != nil {
+		t.Errorf("unexpected error for agreeing edge weights: %v", err)
+	}
+	if _, ok := got.(*UndirectedGraph); !ok {
+		t.Errorf("unexpected union graph type: %T", got)
+	}
+
+	nan := NewDirectedGraph(0, math.Inf(1))
+	nan.SetEdge(Edge{F: Node(0), T: Node(1), W: math.NaN()})
+	got, err = Union(nan, nan)
+	if err != nil {
+		t.Errorf("unexpected error for NaN edge weights: %v", err)
+	} else if !Equal(got, nan) {
+		t.Errorf("unexpected union of NaN weighted graph: got edges:%v", got.(edgeLister).Edges())
+	}
+
+	if _, err := Union(a, ua); err == nil {
+		t.Error("expected error for mixed directed and undirected graphs")
+	}
+}
+
+func TestIntersection(t *testing.T) {
+	a := NewDirectedGraph(0, math.Inf(1))
+	for _, e := range []Edge{
+		{F: Node(0), T: Node(1), W: 1},
+		{F: Node(1), T: Node(2), W: 2},
+		{F: Node(2), T: Node(0), W: 3},
+		{F: Node(2), T: Node(3), W: 4},
+	} {
+		a.SetEdge(e)
+	}
+	b := NewDirectedGraph(0, math.Inf(1))
+	for _, e := range []Edge{
+		{F: Node(0), T: Node(1), W: 1},
+		{F: Node(1), T: Node(2), W: 5},
+		{F: Node(0), T: Node(2), W: 3},
+		{F: Node(1), T: Node(4), W: 6},
+	} {
+		b.SetEdge(e)
+	}
+
+	// The shared edge from 1 to 2 takes the weight in a,
+	// and the edge from 2 to 0 is absent since b only holds
+	// the reverse edge.
+	want := NewDirectedGraph(0, math.Inf(1))
+	for _, e := range []Edge{
+		{F: Node(0), T: Node(1), W: 1},
+		{F: Node(1), T: Node(2), W: 2},
+	} {
+		want.SetEdge(e)
+	}
+	got := Intersection(a, b)
+	if !Equal(got, want) {
+		t.Errorf("unexpected intersection: got edges:%v want edges:%v", got.(edgeLister).Edges(), want.Edges())
+	}
+
+	ua := NewUndirectedGraph(0, math.Inf(1))
+	ub := NewUndirectedGraph(0, math.Inf(1))
+	for _, e := range []Edge{
+		{F: Node(0), T: Node(1), W: 1},
+		{F: Node(1), T: Node(2), W: 2},
+		{F: Node(2), T: Node(3), W: 4},
+	} {
+		ua.SetEdge(e)
+	}
+	for _, e := range []Edge{
+		{F: Node(2), T: Node(1), W: 7},
+		{F: Node(0), T: Node(2), W: 3},
+		{F: Node(4), T: Node(5), W: 6},
+	} {
+		ub.SetEdge(e)
+	}
+	uwant := NewUndirectedGraph(0, math.Inf(1))
+	uwant.SetEdge(Edge{F: Node(1), T: Node(2), W: 2})
+	uwant.AddNode(Node(0))
+	got = Intersection(ua, ub)
+	if !Equal(got, uwant) {
+		t.Errorf("unexpected undirected intersection: got edges:%v want edges:%v", got.(edgeLister).Edges(), uwant.Edges())
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected panic for mixed directed and undirected graphs")
+			}
+		}()
+		Intersection(a, ua)
+	}()
+}
+
+func TestComplement(t *testing.T) {
+	const n = 4
+
+	complete := NewUndirectedGraph(0, math.Inf(1))
+	completeDirected := NewDirectedGraph(0, math.Inf(1))
+	empty := NewUndirectedGraph(0, 2)
+	emptyDirected := NewDirectedGraph(0, 2)
+	for u := 0; u < n; u++ {
+		empty.AddNode(Node(u))
+		emptyDirected.AddNode(Node(u))
+		for v := 0; v < n; v++ {
+			if u != v {
+				complete.SetEdge(Edge{F: Node(u), T: Node(v), W: 1})
+				completeDirected.SetEdge(Edge{F: Node(u), T: Node(v), W: 1})
+			}
+		}
+	}
+
+	for _, test := range []struct {
+		name string
+		g    graph.Graph
+		want int
+	}{
+		{name: "complete", g: complete, want: 0},
+		{name: "complete directed", g: completeDirected, want: 0},
+		{name: "empty", g: empty, want: n * (n - 1) / 2},
+		{name: "empty directed", g: emptyDirected, want: n * (n - 1)},
+	} {
+		c := Complement(test.g, 3)
+		if len(c.Nodes()) != n {
+			t.Errorf("unexpected number of nodes in complement of %s graph: got:%d want:%d", test.name, len(c.Nodes()), n)
+		}
+		edges := c.(edgeLister).Edges()
+		if len(edges) != test.want {
+			t.Errorf("unexpected number of edges in complement of %s graph: got:%d want:%d", test.name, len(edges), test.want)
+		}
+		for _, e := range edges {
+			if e.Weight() != 3 {
+				t.Errorf("unexpected weight for edge %d->%d in complement of %s graph: got:%v want:3",
+					e.From().ID(), e.To().ID(), test.name, e.Weight())
+			}
+		}
+		// The complement of the complement is the original graph
+		// up to edge weights.
+		cc := Complement(c, 1)
+		for _, u := range test.g.Nodes() {
+			if !sameNodes(cc.From(u), test.g.From(u)) {
+				t.Errorf("unexpected double complement neighbors of %d for %s graph: got:%v want:%v",
+					u.ID(), test.name, cc.From(u), test.g.From(u))
+			}
+		}
+	}
+
+	// A directed graph with an edge in only one direction between
+	// two nodes has the reverse edge in its complement.
+	g := NewDirectedGraph(0, math.Inf(1))
+	g.SetEdge(Edge{F: Node(0), T: Node(1), W: 1})
+	c := Complement(g, 1).(graph.Directed)
+	if c.HasEdgeFromTo(Node(0), Node(1)) || !c.HasEdgeFromTo(Node(1), Node(0)) {
+		t.Error("unexpected directed complement edges")
+	}
+
+	// The edges of the complement are not absent edges.
+	empty = NewUndirectedGraph(0, 0)
+	for u := 0; u < 3; u++ {
+		empty.AddNode(Node(u))
+	}
+	m := AdjacencyMatrix(Complement(empty, 1), empty.Nodes())
+	r, _ := m.Dims()
+	for i := 0; i < r; i++ {
+		for j := 0; j < r; j++ {
+			want := 1.0
+			if i == j {
+				want = 0
+			}
+			if got := m.At(i, j); got != want {
+				t.Errorf("unexpected complement adjacency at (%d, %d): got:%v want:%v", i, j, got, want)
+			}
+		}
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected panic for absent complement edge weight")
+			}
+		}()
+		Complement(empty, 0)
+	}()
+}
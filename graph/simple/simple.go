@@ -58,20 +58,26 @@ func newIDSet() idSet {
 }
 
 // newID returns a new unique ID. The ID returned is not considered used
-// until passed in a call to use.
+// until passed in a call to use. Freed IDs are reused before the maximum
+// ID is extended, and the returned ID is never in use. When the maximum ID
+// cannot be extended, the smallest unused non-negative ID is returned; the
+// caller must ensure that fewer than maxInt IDs are in use.
 func (s *idSet) newID() int {
 	for id := range s.free {
-		return id
+		if !s.used.Has(id) {
+			return id
+		}
+		// Clean up a stale free ID.
+		s.free.Remove(id)
 	}
-	if s.maxID != maxInt {
+	if s.maxID != maxInt && !s.used.Has(s.maxID+1) {
 		return s.maxID + 1
 	}
-	for id := 0; id <= s.maxID+1; id++ {
+	for id := 0; ; id++ {
 		if !s.used.Has(id) {
 			return id
 		}
 	}
-	panic("unreachable")
 }
 
 // use adds the id to the used IDs in the idSet.
@@ -88,3 +94,24 @@ func (s *idSet) release(id int) {
 	s.free.Add(id)
 	s.used.Remove(id)
 }
+
+// clone returns a copy of the idSet that shares no state with s.
+func (s *idSet) clone() idSet {
+	c := idSet{maxID: s.maxID, used: make(set.Ints, len(s.used)), free: make(set.Ints, len(s.free))}
+	for id := range s.used {
+		c.used.Add(id)
+	}
+	for id := range s.free {
+		c.free.Add(id)
+	}
+	return c
+}
+
+// copyEdges returns a copy of the edge map m.
+func copyEdges(m map[int]graph.Edge) map[int]graph.Edge {
+	c := make(map[int]graph.Edge, len(m))
+	for id, e := range m {
+		c[id] = e
+	}
+	return c
+}
@@ -0,0 +1,92 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+type nodeIDGraph interface {
+	graph.Graph
+	graph.NodeAdder
+	graph.NodeRemover
+}
+
+func TestNewNodeIDNoCollision(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		g    func() nodeIDGraph
+	}{
+		{name: "directed", g: func() nodeIDGraph { return NewDirectedGraph(0, math.Inf(1)) }},
+		{name: "undirected", g: func() nodeIDGraph { return NewUndirectedGraph(0, math.Inf(1)) }},
+		{name: "directed multigraph", g: func() nodeIDGraph { return NewDirectedMultigraph(0, math.Inf(1)) }},
+	} {
+		rnd := rand.New(rand.NewSource(1))
+		g := test.g()
+		live := make(map[int]bool)
+		for i := 0; i < 10000; i++ {
+			switch r := rnd.Float64(); {
+			case r < 0.4 && len(live) != 0:
+				// Remove a node, half of the time the node
+				// with the maximum live ID so that the
+				// maximum ID is freed.
+				var id int
+				if rnd.Intn(2) == 0 {
+					id = -maxInt - 1
+					for l := range live {
+						if l > id {
+							id = l
+						}
+					}
+				} else {
+					for id = range live {
+						break
+					}
+				}
+				g.RemoveNode(Node(id))
+				delete(live, id)
+			case r < 0.45:
+				// Add a node with an explicit ID.
+				id := rnd.Intn(200) - 10
+				if !live[id] {
+					g.AddNode(Node(id))
+					live[id] = true
+				}
+			case r < 0.46 && !live[maxInt]:
+				// Exhaust the ID space above the maximum.
+				g.AddNode(Node(maxInt))
+				live[maxInt] = true
+			default:
+				id := g.NewNodeID()
+				if live[id] {
+					t.Fatalf("NewNodeID returned live ID %d for %s graph at step %d", id, test.name, i)
+				}
+				g.AddNode(Node(id))
+				live[id] = true
+			}
+		}
+		if len(g.Nodes()) != len(live) {
+			t.Errorf("unexpected number of nodes for %s graph: got:%d want:%d", test.name, len(g.Nodes()), len(live))
+		}
+	}
+}
+
+func TestRestoreIDsInconsistent(t *testing.T) {
+	// An encoding with a free ID in use and a maximum ID
+	// less than a used ID must not cause a collision.
+	enc := gobGraph{Nodes: []int{0, 1, 5}, MaxID: 1, Free: []int{1, 3}}
+	g := enc.directed()
+	for i := 0; i < 10; i++ {
+		id := g.NewNodeID()
+		if g.Has(Node(id)) {
+			t.Fatalf("NewNodeID returned live ID %d", id)
+		}
+		g.AddNode(Node(id))
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"sync"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// SyncGraph is a DirectedGraph that is safe for concurrent use by multiple
+// goroutines. Methods reading the graph hold a read lock and methods
+// modifying it hold a write lock for the duration of the call.
+//
+// Each call is atomic, but a sequence of calls is not, so a node ID returned
+// by NewNodeID may have been taken by another goroutine before it is passed
+// to AddNode. NewNode allocates and adds a node in a single call.
+type SyncGraph struct {
+	mu sync.RWMutex
+	g  *DirectedGraph
+}
+
+// NewSyncGraph returns a SyncGraph guarding g. The caller must not use g
+// directly after the call.
+func NewSyncGraph(g *DirectedGraph) *SyncGraph {
+	return &SyncGraph{g: g}
+}
+
+// NewNodeID returns a new unique ID for a node to be added to g. The returned
+// ID does not become a valid ID in g until it is added to g.
+func (g *SyncGraph) NewNodeID() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.g.NewNodeID()
+}
+
+// NewNode adds a Node with a new unique ID to g and returns it.
+func (g *SyncGraph) NewNode() graph.Node {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	n := Node(g.g.NewNodeID())
+	g.g.AddNode(n)
+	return n
+}
+
+// AddNode adds n to the graph. It panics if the added node ID matches an
+// existing node ID.
+func (g *SyncGraph) AddNode(n graph.Node) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.g.AddNode(n)
+}
+
+// RemoveNode removes n from the graph, as well as any edges attached to it.
+// If the node is not in the graph it is a no-op.
+func (g *SyncGraph) RemoveNode(n graph.Node) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.g.RemoveNode(n)
+}
+
+// SetEdge adds e, an edge from one node to another. If the nodes do not
+// exist, they are added. It will panic if the IDs of the e.From and e.To are
+// equal.
+func (g *SyncGraph) SetEdge(e graph.Edge) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.g.SetEdge(e)
+}
+
+// RemoveEdge removes e from the graph, leaving the terminal nodes. If the
+// edge does not exist it is a no-op.
+func (g *SyncGraph) RemoveEdge(e graph.Edge) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.g.RemoveEdge(e)
+}
+
+// Has returns whether the node exists within the graph.
+func (g *SyncGraph) Has(n graph.Node) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.Has(n)
+}
+
+// Nodes returns all the nodes in the graph.
+func (g *SyncGraph) Nodes() []graph.Node {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.Nodes()
+}
+
+// Edges returns all the edges in the graph.
+func (g *SyncGraph) Edges() []graph.Edge {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.Edges()
+}
+
+// From returns all nodes in g that can be reached directly from n.
+func (g *SyncGraph) From(n graph.Node) []graph.Node {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.From(n)
+}
+
+// To returns all nodes in g that can reach directly to n.
+func (g *SyncGraph) To(n graph.Node) []graph.Node {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.To(n)
+}
+
+// HasEdgeBetween returns whether an edge exists between nodes x and y without
+// considering direction.
+func (g *SyncGraph) HasEdgeBetween(x, y graph.Node) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.HasEdgeBetween(x, y)
+}
+
+// HasEdgeFromTo returns whether an edge exists in the graph from u to v.
+func (g *SyncGraph) HasEdgeFromTo(u, v graph.Node) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.HasEdgeFromTo(u, v)
+}
+
+// Edge returns the edge from u to v if such an edge exists and nil otherwise.
+// The node v must be directly reachable from u as defined by the From method.
+func (g *SyncGraph) Edge(u, v graph.Node) graph.Edge {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.Edge(u, v)
+}
+
+// Weight returns the weight for the edge between x and y if Edge(x, y)
+// returns a non-nil Edge, as described by DirectedGraph's Weight method.
+func (g *SyncGraph) Weight(x, y graph.Node) (w float64, ok bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.Weight(x, y)
+}
+
+// Degree returns the in+out degree of n in g.
+func (g *SyncGraph) Degree(n graph.Node) int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.Degree(n)
+}
+
+// Self returns the weight returned by Weight for self edges.
+func (g *SyncGraph) Self() float64 {
+	return g.g.Self()
+}
+
+// Absent returns the weight returned by Weight for absent edges.
+func (g *SyncGraph) Absent() float64 {
+	return g.g.Absent()
+}
@@ -0,0 +1,80 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"math"
+	"sync"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+var (
+	_ graph.DirectedBuilder = (*SyncGraph)(nil)
+	_ graph.NodeRemover     = (*SyncGraph)(nil)
+	_ graph.EdgeRemover     = (*SyncGraph)(nil)
+	_ graph.Weighter        = (*SyncGraph)(nil)
+)
+
+// TestSyncGraph is most useful when run with the race detector.
+func TestSyncGraph(t *testing.T) {
+	const (
+		workers = 8
+		nodes   = 100
+	)
+	g := NewSyncGraph(NewDirectedGraph(0, math.Inf(1)))
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < nodes; i++ {
+				u := g.NewNode()
+				v := g.NewNode()
+				g.SetEdge(Edge{F: u, T: v, W: 1})
+				g.From(u)
+				g.To(v)
+				g.HasEdgeFromTo(u, v)
+				g.Nodes()
+			}
+		}()
+	}
+
+	// Nodes with explicit negative IDs do not collide with those
+	// allocated by NewNode and are all removed before the end, so
+	// only the NewNode nodes and their edges remain.
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < nodes; i++ {
+				u := Node(-2 * (w*nodes + i + 1))
+				v := u - 1
+				g.AddNode(u)
+				g.AddNode(v)
+				g.SetEdge(Edge{F: u, T: v, W: 1})
+				g.Has(u)
+				g.Edges()
+				g.RemoveNode(u)
+				g.RemoveNode(v)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if n := len(g.Nodes()); n != 2*workers*nodes {
+		t.Errorf("unexpected number of nodes: got:%d want:%d", n, 2*workers*nodes)
+	}
+	if n := len(g.Edges()); n != workers*nodes {
+		t.Errorf("unexpected number of edges: got:%d want:%d", n, workers*nodes)
+	}
+	for _, e := range g.Edges() {
+		if g.Degree(e.From()) != 1 || g.Degree(e.To()) != 1 {
+			t.Errorf("unexpected degrees for edge %d->%d", e.From().ID(), e.To().ID())
+		}
+	}
+}
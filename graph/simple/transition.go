@@ -0,0 +1,151 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/mat"
+)
+
+// TransitionMatrix returns the row-stochastic transition matrix of g and the
+// node IDs indexing its rows and columns, which are sorted in ascending
+// order. Element (i, j) is the weight of the edge from node i to node j
+// divided by the sum of the weights of the edges from node i, so each row
+// of a node with outgoing edges sums to one. Rows of dangling nodes, nodes
+// with no outgoing edges or with zero out-strength, are all zero.
+//
+// If g is a graph.Weighter the weights are obtained from its Weight method,
+// otherwise they are obtained from the edges' Weight method. If g has an
+// Absent method, edges carrying the absent weight it returns are ignored, as
+// for AdjacencyMatrix. The weights must be non-negative.
+func TransitionMatrix(g graph.Directed) (*mat.Dense, []int) {
+	nodes := g.Nodes()
+	sort.Sort(ordered.ByID(nodes))
+	ids := make([]int, len(nodes))
+	indexOf := make(map[int]int, len(nodes))
+	for i, n := range nodes {
+		ids[i] = n.ID()
+		indexOf[n.ID()] = i
+	}
+	if len(nodes) == 0 {
+		return &mat.Dense{}, ids
+	}
+
+	isAbsent := absentWeightFunc(g)
+	weight := edgeWeightFunc(g)
+	p := mat.NewDense(len(nodes), len(nodes), nil)
+	for i, u := range nodes {
+		var strength float64
+		for _, v := range g.From(u) {
+			w := weight(u, v)
+			if isAbsent(w) {
+				continue
+			}
+			p.Set(i, indexOf[v.ID()], w)
+			strength += w
+		}
+		if strength == 0 {
+			continue
+		}
+		row := p.RawRowView(i)
+		for j := range row {
+			row[j] /= strength
+		}
+	}
+	return p, ids
+}
+
+// HittingTimes returns the expected number of steps for a random walk on g
+// to first reach the node with ID target from each node of g, keyed by node
+// ID. The walk follows the transition matrix returned by TransitionMatrix.
+// The hitting time of the target is zero. Nodes from which the walk may
+// never reach the target, including nodes that cannot reach it at all, have
+// an infinite hitting time. If the target is not in g, HittingTimes returns
+// nil.
+//
+// The finite hitting times h are the solution of the linear system
+//  h_i = 1 + \sum_j P_ij h_j
+// over the nodes that reach the target with probability one.
+func HittingTimes(g graph.Directed, target int) map[int]float64 {
+	if !g.Has(Node(target)) {
+		return nil
+	}
+	p, ids := TransitionMatrix(g)
+	n := len(ids)
+	t := sort.SearchInts(ids, target)
+
+	// Find the nodes that cannot reach the target, and then the
+	// nodes that may reach those before reaching the target.
+	reach := make([]bool, n)
+	reach[t] = true
+	queue := []int{t}
+	for len(queue) != 0 {
+		j := queue[0]
+		queue = queue[1:]
+		for i := 0; i < n; i++ {
+			if !reach[i] && p.At(i, j) > 0 {
+				reach[i] = true
+				queue = append(queue, i)
+			}
+		}
+	}
+	finite := make([]bool, n)
+	for i := range finite {
+		finite[i] = reach[i]
+		if !reach[i] {
+			queue = append(queue, i)
+		}
+	}
+	for len(queue) != 0 {
+		j := queue[0]
+		queue = queue[1:]
+		for i := 0; i < n; i++ {
+			if finite[i] && i != t && p.At(i, j) > 0 {
+				finite[i] = false
+				queue = append(queue, i)
+			}
+		}
+	}
+
+	times := make(map[int]float64, n)
+	var idx []int
+	for i, id := range ids {
+		switch {
+		case i == t:
+			times[id] = 0
+		case !finite[i]:
+			times[id] = math.Inf(1)
+		default:
+			idx = append(idx, i)
+		}
+	}
+	if len(idx) == 0 {
+		return times
+	}
+
+	// Solve (I - P_FF) h = 1 over the finite nodes F
+	// other than the target.
+	a := mat.NewDense(len(idx), len(idx), nil)
+	b := mat.NewVector(len(idx), nil)
+	for r, i := range idx {
+		for c, j := range idx {
+			a.Set(r, c, -p.At(i, j))
+		}
+		a.Set(r, r, a.At(r, r)+1)
+		b.SetVec(r, 1)
+	}
+	var h mat.Vector
+	if err := h.SolveVec(a, b); err != nil {
+		panic("simple: singular hitting time system")
+	}
+	for r, i := range idx {
+		times[ids[i]] = h.At(r, 0)
+	}
+	return times
+}
@@ -0,0 +1,126 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+func TestTransitionMatrix(t *testing.T) {
+	g := NewDirectedGraph(0, math.Inf(1))
+	for _, e := range []Edge{
+		{F: Node(0), T: Node(1), W: 1},
+		{F: Node(0), T: Node(3), W: 3},
+		{F: Node(1), T: Node(0), W: 2},
+		{F: Node(1), T: Node(2), W: 2},
+		{F: Node(1), T: Node(3), W: 4},
+	} {
+		g.SetEdge(e)
+	}
+
+	p, ids := TransitionMatrix(g)
+	if want := []int{0, 1, 2, 3}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("unexpected node IDs: got:%v want:%v", ids, want)
+	}
+	for i, id := range ids {
+		row := p.RawRowView(i)
+		out := g.From(Node(id))
+		if len(out) == 0 {
+			if !floats.Equal(row, make([]float64, len(ids))) {
+				t.Errorf("unexpected non-zero row for dangling node %d: %v", id, row)
+			}
+			continue
+		}
+		if sum := floats.Sum(row); math.Abs(sum-1) > 1e-14 {
+			t.Errorf("unexpected row sum for node %d: got:%v want:1", id, sum)
+		}
+
+		// Entries are proportional to the edge weights.
+		var strength float64
+		for _, v := range out {
+			w, _ := g.Weight(Node(id), v)
+			strength += w
+		}
+		for _, v := range out {
+			w, _ := g.Weight(Node(id), v)
+			if got, want := p.At(i, v.ID()), w/strength; math.Abs(got-want) > 1e-14 {
+				t.Errorf("unexpected transition probability %d->%d: got:%v want:%v", id, v.ID(), got, want)
+			}
+		}
+	}
+
+	// Edges carrying the absent weight are ignored, as they
+	// are by AdjacencyMatrix.
+	const absent = 5
+	g = NewDirectedGraph(0, absent)
+	for _, e := range []Edge{
+		{F: Node(0), T: Node(1), W: 1},
+		{F: Node(0), T: Node(2), W: absent},
+		{F: Node(0), T: Node(3), W: 3},
+		{F: Node(2), T: Node(0), W: absent},
+	} {
+		g.SetEdge(e)
+	}
+	p, _ = TransitionMatrix(g)
+	want := [][]float64{
+		{0, 0.25, 0, 0.75},
+		{0, 0, 0, 0},
+		{0, 0, 0, 0},
+		{0, 0, 0, 0},
+	}
+	for i, w := range want {
+		if row := p.RawRowView(i); !floats.Equal(row, w) {
+			t.Errorf("unexpected transition row %d with absent edges: got:%v want:%v", i, row, w)
+		}
+	}
+}
+
+func TestHittingTimes(t *testing.T) {
+	// A directed chain 5->4->3->2->1->0 where the hitting
+	// time of 0 increases linearly along the chain, and a
+	// node 6 that cannot reach 0.
+	g := NewDirectedGraph(0, math.Inf(1))
+	for i := 5; i > 0; i-- {
+		g.SetEdge(Edge{F: Node(i), T: Node(i - 1), W: 1})
+	}
+	g.SetEdge(Edge{F: Node(0), T: Node(6), W: 1})
+	got := HittingTimes(g, 0)
+	for i := 0; i <= 5; i++ {
+		if math.Abs(got[i]-float64(i)) > 1e-12 {
+			t.Errorf("unexpected hitting time from %d: got:%v want:%d", i, got[i], i)
+		}
+	}
+	if !math.IsInf(got[6], 1) {
+		t.Errorf("unexpected hitting time from unreachable node: got:%v want:+Inf", got[6])
+	}
+
+	// 1 steps to 0 or 2 with equal probability and 2 returns to 1,
+	// so h_1 = 1 + h_2/2 and h_2 = 1 + h_1. 3 may step to the dead
+	// end 4 and so may never reach 0.
+	g = NewDirectedGraph(0, math.Inf(1))
+	for _, e := range []Edge{
+		{F: Node(1), T: Node(0), W: 1},
+		{F: Node(1), T: Node(2), W: 1},
+		{F: Node(2), T: Node(1), W: 1},
+		{F: Node(3), T: Node(1), W: 1},
+		{F: Node(3), T: Node(4), W: 1},
+	} {
+		g.SetEdge(e)
+	}
+	got = HittingTimes(g, 0)
+	want := map[int]float64{0: 0, 1: 3, 2: 4, 3: math.Inf(1), 4: math.Inf(1)}
+	for id, w := range want {
+		if got[id] != w && math.Abs(got[id]-w) > 1e-12 {
+			t.Errorf("unexpected hitting time from %d: got:%v want:%v", id, got[id], w)
+		}
+	}
+	if got := HittingTimes(g, 10); got != nil {
+		t.Errorf("unexpected hitting times for absent target: got:%v want:<nil>", got)
+	}
+}
@@ -172,6 +172,18 @@ func (g *UndirectedGraph) From(n graph.Node) []graph.Node {
 	return nodes
 }
 
+// FromIter calls fn for each node in g that can be reached directly from the
+// node with the given ID, stopping if fn returns false. Unlike From, FromIter
+// does not allocate a slice of the nodes. The graph must not be modified
+// during the iteration.
+func (g *UndirectedGraph) FromIter(id int, fn func(graph.Node) bool) {
+	for vid := range g.edges[id] {
+		if !fn(g.nodes[vid]) {
+			return
+		}
+	}
+}
+
 // HasEdgeBetween returns whether an edge exists between nodes x and y.
 func (g *UndirectedGraph) HasEdgeBetween(x, y graph.Node) bool {
 	_, ok := g.edges[x.ID()][y.ID()]
@@ -213,6 +225,16 @@ func (g *UndirectedGraph) Weight(x, y graph.Node) (w float64, ok bool) {
 	return g.absent, false
 }
 
+// Self returns the weight returned by Weight for self edges.
+func (g *UndirectedGraph) Self() float64 {
+	return g.self
+}
+
+// Absent returns the weight returned by Weight for absent edges.
+func (g *UndirectedGraph) Absent() float64 {
+	return g.absent
+}
+
 // Degree returns the degree of n in g.
 func (g *UndirectedGraph) Degree(n graph.Node) int {
 	if _, ok := g.nodes[n.ID()]; !ok {
@@ -221,3 +243,25 @@ func (g *UndirectedGraph) Degree(n graph.Node) int {
 
 	return len(g.edges[n.ID()])
 }
+
+// Copy returns a deep copy of g. The copy holds the same nodes and edges,
+// and has the same self and absent weights and node ID allocation state,
+// but shares no maps with g, so mutating the copy does not affect g.
+func (g *UndirectedGraph) Copy() *UndirectedGraph {
+	c := &UndirectedGraph{
+		nodes: make(map[int]graph.Node, len(g.nodes)),
+		edges: make(map[int]map[int]graph.Edge, len(g.edges)),
+
+		self:   g.self,
+		absent: g.absent,
+
+		nodeIDs: g.nodeIDs.clone(),
+	}
+	for id, n := range g.nodes {
+		c.nodes[id] = n
+	}
+	for id, edges := range g.edges {
+		c.edges[id] = copyEdges(edges)
+	}
+	return c
+}
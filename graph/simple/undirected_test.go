@@ -61,3 +61,76 @@ func TestIssue123UndirectedGraph(t *testing.T) {
 	n2 := Node(g.NewNodeID())
 	g.AddNode(n2)
 }
+
+func TestUndirectedGraphSelfAbsent(t *testing.T) {
+	for _, test := range []struct{ self, absent float64 }{
+		{self: 0, absent: math.Inf(1)},
+		{self: -1, absent: 1e9},
+		{self: math.Inf(-1), absent: -2.5},
+	} {
+		g := NewUndirectedGraph(test.self, test.absent)
+		if got := g.Self(); got != test.self {
+			t.Errorf("unexpected self weight: got:%v want:%v", got, test.self)
+		}
+		if got := g.Absent(); got != test.absent {
+			t.Errorf("unexpected absent weight: got:%v want:%v", got, test.absent)
+		}
+	}
+}
+
+func TestUndirectedGraphCopy(t *testing.T) {
+	g := NewUndirectedGraph(-1, math.Inf(1))
+	for _, e := range []Edge{
+		{F: Node(0), T: Node(1), W: 2},
+		{F: Node(1), T: Node(2), W: 0.5},
+		{F: Node(2), T: Node(0), W: 1},
+	} {
+		g.SetEdge(e)
+	}
+	g.RemoveNode(Node(2))
+	g.SetEdge(Edge{F: Node(1), T: Node(3), W: 3})
+
+	c := g.Copy()
+	if c.Self() != g.Self() || c.Absent() != g.Absent() {
+		t.Errorf("unexpected self and absent weights: got:%v and %v want:%v and %v", c.Self(), c.Absent(), g.Self(), g.Absent())
+	}
+	for _, e := range g.Edges() {
+		if w, ok := c.Weight(e.To(), e.From()); !ok || w != e.Weight() {
+			t.Errorf("unexpected weight for copied edge %d--%d: got:%v want:%v", e.From().ID(), e.To().ID(), w, e.Weight())
+		}
+	}
+	if got, want := c.NewNodeID(), g.NewNodeID(); got != want {
+		t.Errorf("unexpected new node ID: got:%d want:%d", got, want)
+	}
+
+	c.RemoveEdge(Edge{F: Node(1), T: Node(0)})
+	if c.HasEdgeBetween(Node(0), Node(1)) {
+		t.Error("edge not removed from copy")
+	}
+	if !g.HasEdgeBetween(Node(0), Node(1)) || !g.HasEdgeBetween(Node(1), Node(0)) {
+		t.Error("removing edge from copy removed it from original")
+	}
+	c.RemoveNode(Node(3))
+	if !g.Has(Node(3)) || g.Degree(Node(1)) != 2 {
+		t.Error("removing node from copy changed original")
+	}
+}
+
+func TestUndirectedGraphFromIter(t *testing.T) {
+	g := NewUndirectedGraph(0, math.Inf(1))
+	for _, e := range randomEdges(200, 20) {
+		g.SetEdge(e)
+	}
+	g.AddNode(Node(20))
+
+	for _, u := range g.Nodes() {
+		var from []graph.Node
+		g.FromIter(u.ID(), func(v graph.Node) bool {
+			from = append(from, v)
+			return true
+		})
+		if !sameNodes(from, g.From(u)) {
+			t.Errorf("unexpected FromIter nodes for %d: got:%v want:%v", u.ID(), from, g.From(u))
+		}
+	}
+}
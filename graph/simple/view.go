@@ -0,0 +1,163 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// InducedView returns a read-only view of the subgraph of g induced by the
+// node IDs that are true in ids. The view holds no adjacency of its own;
+// its methods query g and drop any nodes and edges with an end outside the
+// ID set, so nodes, edges and weights are those of g. The returned view is a
+// graph.Directed if g is a graph.Directed, and a graph.Undirected if g is a
+// graph.Undirected. The view is a graph.Weighter, and its self and absent
+// weights are those of g if g has Self and Absent methods, and 0 and +Inf
+// otherwise.
+//
+// The view holds g and ids rather than copies of them, so it is invalidated
+// if either is modified; nodes and edges already obtained from the view may
+// no longer match it. Use Subgraph to obtain an independent copy.
+func InducedView(g graph.Graph, ids map[int]bool) graph.Graph {
+	v := inducedView{g: g, ids: ids}
+	switch g := g.(type) {
+	case graph.Directed:
+		return directedInducedView{inducedView: v, g: g}
+	case graph.Undirected:
+		return undirectedInducedView{inducedView: v, g: g}
+	default:
+		return v
+	}
+}
+
+// inducedView is a view of the subgraph of g induced by ids.
+type inducedView struct {
+	g   graph.Graph
+	ids map[int]bool
+}
+
+// Has returns whether the node exists within the view.
+func (v inducedView) Has(n graph.Node) bool {
+	return v.ids[n.ID()] && v.g.Has(n)
+}
+
+// Nodes returns all the nodes in the view.
+func (v inducedView) Nodes() []graph.Node {
+	var nodes []graph.Node
+	for _, n := range v.g.Nodes() {
+		if v.ids[n.ID()] {
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes
+}
+
+// From returns all nodes in the view that can be reached directly from n.
+func (v inducedView) From(n graph.Node) []graph.Node {
+	if !v.ids[n.ID()] {
+		return nil
+	}
+	return v.filter(v.g.From(n))
+}
+
+// filter returns the nodes in the ID set of the view.
+func (v inducedView) filter(nodes []graph.Node) []graph.Node {
+	var kept []graph.Node
+	for _, n := range nodes {
+		if v.ids[n.ID()] {
+			kept = append(kept, n)
+		}
+	}
+	return kept
+}
+
+// HasEdgeBetween returns whether an edge exists between nodes x and y
+// without considering direction.
+func (v inducedView) HasEdgeBetween(x, y graph.Node) bool {
+	return v.ids[x.ID()] && v.ids[y.ID()] && v.g.HasEdgeBetween(x, y)
+}
+
+// Edge returns the edge from u to w if such an edge exists and nil
+// otherwise.
+func (v inducedView) Edge(u, w graph.Node) graph.Edge {
+	if !v.ids[u.ID()] || !v.ids[w.ID()] {
+		return nil
+	}
+	return v.g.Edge(u, w)
+}
+
+// Weight returns the weight for the edge between x and y if Edge(x, y)
+// returns a non-nil Edge. If g is a graph.Weighter the weight is that
+// returned by g, otherwise it is the weight of the edge. If x and y are the
+// same node in the view or there is no joining edge in the view the weight
+// value returned is either the view's self or absent value. Weight returns
+// true if an edge exists in the view between x and y or if x and y are the
+// same node in the view, false otherwise.
+func (v inducedView) Weight(x, y graph.Node) (w float64, ok bool) {
+	if !v.ids[x.ID()] || !v.ids[y.ID()] {
+		return v.Absent(), false
+	}
+	if wg, ok := v.g.(graph.Weighter); ok {
+		return wg.Weight(x, y)
+	}
+	if x.ID() == y.ID() {
+		return v.Self(), true
+	}
+	if e := v.g.Edge(x, y); e != nil {
+		return e.Weight(), true
+	}
+	return v.Absent(), false
+}
+
+// Self returns the weight returned by Weight for self edges.
+func (v inducedView) Self() float64 {
+	if sa, ok := v.g.(selfAbsenter); ok {
+		return sa.Self()
+	}
+	return 0
+}
+
+// Absent returns the weight returned by Weight for absent edges.
+func (v inducedView) Absent() float64 {
+	if sa, ok := v.g.(selfAbsenter); ok {
+		return sa.Absent()
+	}
+	return math.Inf(1)
+}
+
+// directedInducedView is a view of the subgraph of a directed graph.
+type directedInducedView struct {
+	inducedView
+	g graph.Directed
+}
+
+// HasEdgeFromTo returns whether an edge exists in the view from u to w.
+func (v directedInducedView) HasEdgeFromTo(u, w graph.Node) bool {
+	return v.ids[u.ID()] && v.ids[w.ID()] && v.g.HasEdgeFromTo(u, w)
+}
+
+// To returns all nodes in the view that can reach directly to n.
+func (v directedInducedView) To(n graph.Node) []graph.Node {
+	if !v.ids[n.ID()] {
+		return nil
+	}
+	return v.filter(v.g.To(n))
+}
+
+// undirectedInducedView is a view of the subgraph of an undirected graph.
+type undirectedInducedView struct {
+	inducedView
+	g graph.Undirected
+}
+
+// EdgeBetween returns the edge between nodes x and y.
+func (v undirectedInducedView) EdgeBetween(x, y graph.Node) graph.Edge {
+	if !v.ids[x.ID()] || !v.ids[y.ID()] {
+		return nil
+	}
+	return v.g.EdgeBetween(x, y)
+}
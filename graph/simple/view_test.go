@@ -0,0 +1,109 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+func TestInducedView(t *testing.T) {
+	dg := NewDirectedGraph(0, math.Inf(1))
+	ug := NewUndirectedGraph(0, math.Inf(1))
+	for _, e := range randomEdges(200, 20) {
+		dg.SetEdge(e)
+		ug.SetEdge(e)
+	}
+	ids := map[int]bool{1: true, 2: true, 3: true, 5: true, 8: true, 13: true, 4: false, 30: true}
+	var subset []int
+	for id, ok := range ids {
+		if ok {
+			subset = append(subset, id)
+		}
+	}
+
+	for _, test := range []struct {
+		name string
+		g    graph.Graph
+	}{
+		{name: "directed", g: dg},
+		{name: "undirected", g: ug},
+	} {
+		view := InducedView(test.g, ids)
+		want := Subgraph(test.g, subset)
+
+		_, gDirected := test.g.(graph.Directed)
+		_, vDirected := view.(graph.Directed)
+		if gDirected != vDirected {
+			t.Errorf("unexpected directedness of %s view", test.name)
+		}
+		if _, ok := view.(graph.Undirected); ok == gDirected {
+			t.Errorf("unexpected undirectedness of %s view", test.name)
+		}
+		if !Equal(view, want) {
+			t.Errorf("%s view does not match subgraph", test.name)
+		}
+		for _, id := range []int{4, 30, 0} {
+			if view.Has(Node(id)) {
+				t.Errorf("unexpected node %d in %s view", id, test.name)
+			}
+		}
+		for _, u := range test.g.Nodes() {
+			for _, v := range test.g.From(u) {
+				in := ids[u.ID()] && ids[v.ID()]
+				if got := view.Edge(u, v) != nil; got != in {
+					t.Errorf("unexpected edge %d->%d presence in %s view: got:%t want:%t", u.ID(), v.ID(), test.name, got, in)
+				}
+				if got := view.HasEdgeBetween(u, v); got != in {
+					t.Errorf("unexpected edge %d--%d presence in %s view: got:%t want:%t", u.ID(), v.ID(), test.name, got, in)
+				}
+			}
+			if d, ok := view.(graph.Directed); ok {
+				if !sameNodes(d.To(u), want.(graph.Directed).To(u)) {
+					t.Errorf("unexpected To nodes for %d in %s view: got:%v want:%v", u.ID(), test.name, d.To(u), want.(graph.Directed).To(u))
+				}
+			}
+		}
+	}
+
+	// Weights are those of the underlying graph for nodes in the
+	// view, and absent otherwise.
+	fg := NewDirectedGraph(-1, 100)
+	fg.SetEdge(Edge{F: Node(0), T: Node(1), W: 3})
+	fg.SetEdge(Edge{F: Node(1), T: Node(2), W: 5})
+	fv := InducedView(fg, map[int]bool{0: true, 1: true})
+	wv, ok := fv.(graph.Weighter)
+	if !ok {
+		t.Fatal("view is not a graph.Weighter")
+	}
+	for _, test := range []struct {
+		x, y int
+		w    float64
+		ok   bool
+	}{
+		{x: 0, y: 1, w: 3, ok: true},
+		{x: 1, y: 0, w: 100, ok: false},
+		{x: 1, y: 2, w: 100, ok: false},
+		{x: 2, y: 2, w: 100, ok: false},
+		{x: 1, y: 1, w: -1, ok: true},
+	} {
+		w, ok := wv.Weight(Node(test.x), Node(test.y))
+		if w != test.w || ok != test.ok {
+			t.Errorf("unexpected weight for %d->%d: got:(%v, %t) want:(%v, %t)", test.x, test.y, w, ok, test.w, test.ok)
+		}
+	}
+	if sa := fv.(selfAbsenter); sa.Self() != -1 || sa.Absent() != 100 {
+		t.Errorf("unexpected self and absent weights: got:(%v, %v) want:(-1, 100)", sa.Self(), sa.Absent())
+	}
+
+	// The view reflects changes to the underlying graph.
+	view := InducedView(dg, ids)
+	dg.SetEdge(Edge{F: Node(1), T: Node(13), W: 7})
+	if e := view.Edge(Node(1), Node(13)); e == nil || e.Weight() != 7 {
+		t.Errorf("unexpected edge after modifying graph: %v", e)
+	}
+}
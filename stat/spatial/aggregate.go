@@ -0,0 +1,109 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat"
+)
+
+// Aggregate returns the neighbor aggregates of data over the given locality.
+// For each unit i, the values and weights of its neighbors, the units j ≠ i
+// with a non-zero weight w_ij as for RequireNeighbors, are collected and
+// passed to reduce, and the result is stored in the ith element of the
+// returned slice. Self weights on the diagonal of the locality are ignored.
+// The slices passed to reduce are only valid for the duration of the call.
+//
+// Aggregate generalizes the spatial lag: for a locality with a zero diagonal
+// a reduce function returning the weighted sum of the values gives the lag,
+// while other reducers give, for example, the weighted maximum or minimum
+// over neighbors. The locality must
+// be square with dimensions matching the length of data, otherwise Aggregate
+// will panic.
+func Aggregate(data []float64, locality mat.Matrix, reduce func(values, weights []float64) float64) []float64 {
+	checkLocality(len(data), locality)
+
+	agg := make([]float64, len(data))
+	values := make([]float64, 0, len(data))
+	weights := make([]float64, 0, len(data))
+	for i := range data {
+		values = values[:0]
+		weights = weights[:0]
+		for j, v := range data {
+			if j == i {
+				continue
+			}
+			w := locality.At(i, j)
+			if w == 0 {
+				continue
+			}
+			values = append(values, v)
+			weights = append(weights, w)
+		}
+		agg[i] = reduce(values, weights)
+	}
+	return agg
+}
+
+// SpatialLag returns the spatial lag of data over the given locality,
+//  lag_i = \sum_j w_ij x_j.
+// With a row-standardized locality the lag of a unit is the weighted mean of
+// its neighbors' values, and with raw weights it is their weighted sum. The
+// locality must be square with dimensions matching the length of data,
+// otherwise SpatialLag will panic.
+func SpatialLag(data []float64, locality mat.Matrix) []float64 {
+	checkLocality(len(data), locality)
+	lag := make([]float64, len(data))
+	mat.NewVector(len(lag), lag).MulVec(locality, mat.NewVector(len(data), data))
+	return lag
+}
+
+// LocalVariance returns the weighted variance of the neighborhood values of
+// each unit of data over the given locality. The neighborhood of unit i is
+// the set of units j ≠ i with a non-zero weight w_ij, as for Aggregate, and
+// its weighted variance is
+//  \sum_j w_ij (x_j - m_i)^2 / \sum_j w_ij
+// where m_i is the weighted mean of the neighborhood values. Mapping the
+// local variances shows whether variability is itself spatially clustered.
+// Units with no neighbors have a NaN local variance. The locality must be
+// square with dimensions matching the length of data, otherwise
+// LocalVariance will panic.
+func LocalVariance(data []float64, locality mat.Matrix) []float64 {
+	return Aggregate(data, locality, func(values, weights []float64) float64 {
+		if len(values) == 0 {
+			return math.NaN()
+		}
+		return stat.MomentAbout(2, values, stat.Mean(values, weights), weights)
+	})
+}
+
+// RequireNeighbors returns a copy of the local statistics in stats with the
+// statistic of each unit having fewer than minNeighbors neighbors replaced by
+// NaN, so that unreliable values for sparsely connected units are suppressed
+// and classified as not significant by Classify. The neighbors of unit i are
+// the units j ≠ i with a non-zero weight w_ij. Only the returned values are
+// affected; any global quantities, such as the mean and variance used to
+// compute the statistics, are unchanged. The locality must be square with
+// dimensions matching the length of stats, otherwise RequireNeighbors will
+// panic.
+func RequireNeighbors(stats []float64, locality mat.Matrix, minNeighbors int) []float64 {
+	checkLocality(len(stats), locality)
+	out := make([]float64, len(stats))
+	for i, v := range stats {
+		var k int
+		for j := range stats {
+			if j != i && locality.At(i, j) != 0 {
+				k++
+			}
+		}
+		if k < minNeighbors {
+			v = math.NaN()
+		}
+		out[i] = v
+	}
+	return out
+}
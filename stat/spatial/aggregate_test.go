@@ -0,0 +1,149 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestAggregate(t *testing.T) {
+	locality := gridLocality(4, 4)
+	n, _ := locality.Dims()
+
+	// A weighted sum reducer reproduces the spatial lag, Wx.
+	got := Aggregate(clusteredGrid, locality, floats.Dot)
+	want := make([]float64, n)
+	mat.NewVector(n, want).MulVec(locality, mat.NewVector(n, clusteredGrid))
+	if !floats.EqualApprox(got, want, 1e-12) {
+		t.Errorf("unexpected weighted sum aggregate: got:%v want:%v", got, want)
+	}
+
+	// A weighted max reducer.
+	// Self weights are ignored.
+	w := mat.NewDense(3, 3, []float64{
+		5, 1, 2,
+		0.5, 0, 0,
+		3, 0.5, 1,
+	})
+	data := []float64{1, 4, 2}
+	got = Aggregate(data, w, func(values, weights []float64) float64 {
+		max := math.Inf(-1)
+		for k, v := range values {
+			max = math.Max(max, v*weights[k])
+		}
+		return max
+	})
+	want = []float64{4, 0.5, 3}
+	if !floats.Equal(got, want) {
+		t.Errorf("unexpected weighted max aggregate: got:%v want:%v", got, want)
+	}
+}
+
+func TestSpatialLag(t *testing.T) {
+	w := mat.NewDense(3, 3, []float64{
+		0, 1, 2,
+		0.5, 0, 0,
+		3, 0.5, 1,
+	})
+	data := []float64{1, 4, 2}
+	got := SpatialLag(data, w)
+	want := []float64{8, 0.5, 7}
+	if !floats.Equal(got, want) {
+		t.Errorf("unexpected spatial lag: got:%v want:%v", got, want)
+	}
+
+	// With a row-standardized locality the lag of each
+	// unit is the weighted mean of its neighbors.
+	got = SpatialLag(data, rowStandardized(w))
+	want = []float64{8.0 / 3, 1, 7 / 4.5}
+	if !floats.EqualApprox(got, want, 1e-15) {
+		t.Errorf("unexpected row-standardized spatial lag: got:%v want:%v", got, want)
+	}
+}
+
+func TestLocalVariance(t *testing.T) {
+	// A uniform background with a high-variance patch in the
+	// top left corner of a 5×5 grid.
+	data := []float64{
+		9, 1, 8, 5, 5,
+		0, 10, 2, 5, 5,
+		7, 1, 9, 5, 5,
+		5, 5, 5, 5, 5,
+		5, 5, 5, 5, 5,
+	}
+	locality := gridLocality(5, 5)
+	got := LocalVariance(data, locality)
+	for _, i := range []int{0, 1, 5, 6} {
+		for _, j := range []int{18, 19, 23, 24} {
+			if got[i] <= got[j] {
+				t.Errorf("patch unit %d local variance not larger than background unit %d: %v <= %v", i, j, got[i], got[j])
+			}
+		}
+	}
+	for _, j := range []int{18, 19, 23, 24} {
+		if got[j] != 0 {
+			t.Errorf("unexpected local variance for background unit %d: got:%v want:0", j, got[j])
+		}
+	}
+	// Unit 1 has neighbors 0, 2 and 6 with values 9, 8 and 10.
+	if want := 2.0 / 3; math.Abs(got[1]-want) > 1e-12 {
+		t.Errorf("unexpected local variance for unit 1: got:%v want:%v", got[1], want)
+	}
+
+	isolated := mat.NewDense(3, 3, []float64{
+		0, 1, 0,
+		1, 0, 0,
+		0, 0, 0,
+	})
+	if got := LocalVariance([]float64{1, 2, 3}, isolated); !math.IsNaN(got[2]) {
+		t.Errorf("unexpected local variance for isolated unit: got:%v want:NaN", got[2])
+	}
+
+	// A unit with only a self weight has no neighbors, in
+	// agreement with RequireNeighbors.
+	isolated.Set(2, 2, 1)
+	if got := LocalVariance([]float64{1, 2, 3}, isolated); !math.IsNaN(got[2]) {
+		t.Errorf("unexpected local variance for unit with self weight: got:%v want:NaN", got[2])
+	}
+	if got := RequireNeighbors([]float64{1, 2, 3}, isolated, 1); !math.IsNaN(got[2]) {
+		t.Errorf("unexpected statistic for unit with self weight: got:%v want:NaN", got[2])
+	}
+}
+
+func TestRequireNeighbors(t *testing.T) {
+	// Unit 5 is joined only to unit 4.
+	locality := mat.NewDense(6, 6, []float64{
+		0, 1, 1, 0, 0, 0,
+		1, 0, 1, 1, 0, 0,
+		1, 1, 0, 1, 1, 0,
+		0, 1, 1, 0, 1, 0,
+		0, 0, 1, 1, 0, 1,
+		0, 0, 0, 0, 1, 0,
+	})
+	data := []float64{9, 8, 7, 2, 1, 30}
+	_, gstar := GandGstarAll(data, locality)
+	got := RequireNeighbors(gstar, locality, 2)
+	for i, v := range got {
+		if i == 5 {
+			if !math.IsNaN(v) {
+				t.Errorf("unexpected statistic for unit with one neighbor: got:%v want:NaN", v)
+			}
+			continue
+		}
+		if v != gstar[i] {
+			t.Errorf("unexpected statistic for unit %d: got:%v want:%v", i, v, gstar[i])
+		}
+	}
+	if c := Classify(got, 0.05)[5]; c != NotSignificant {
+		t.Errorf("unexpected classification for suppressed unit: got:%v want:%v", c, NotSignificant)
+	}
+	if got := RequireNeighbors(gstar, locality, 1); !floats.Equal(got, gstar) {
+		t.Errorf("unexpected suppression with minNeighbors 1: got:%v want:%v", got, gstar)
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"math"
+	"math/rand"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+// BivariateLocalMoran returns the local bivariate Moran's I statistics for x
+// and y over the given locality. The statistic for unit i is
+//  I_i = zx_i \sum_j w_ij zy_j
+// where zx and zy are the standardized values of x and y. Large positive
+// values indicate that a high (or low) value of x at unit i is surrounded by
+// high (or low) values of y.
+//
+// The statistic is not symmetric in x and y: it relates x at each unit to the
+// spatial lag of y, so exchanging x and y will in general give different
+// results.
+//
+// The locality must be square with dimensions matching the lengths of x and
+// y, otherwise BivariateLocalMoran will panic.
+func BivariateLocalMoran(x, y []float64, locality mat.Matrix) []float64 {
+	if len(x) != len(y) {
+		panic("spatial: data length mismatch")
+	}
+	checkLocality(len(x), locality)
+
+	zx := standardized(x)
+	stats := SpatialLag(standardized(y), locality)
+	for i, v := range zx {
+		stats[i] *= v
+	}
+	return stats
+}
+
+// BivariateLocalMoranPValues returns pseudo p-values for the local bivariate
+// Moran's I statistics of x and y over the given locality using perms
+// conditional permutations. For each unit i, x_i is held fixed while the
+// values of y at its neighbors, the units j ≠ i with a non-zero weight w_ij,
+// are randomly drawn from the values of y at the other units. The p-values
+// are computed by ConditionalPermute. If src is nil, the global rand source
+// is used.
+//
+// BivariateLocalMoranPValues will panic if perms is not positive or for the
+// conditions described for BivariateLocalMoran.
+func BivariateLocalMoranPValues(x, y []float64, locality mat.Matrix, perms int, src rand.Source) []float64 {
+	if len(x) != len(y) {
+		panic("spatial: data length mismatch")
+	}
+	checkLocality(len(x), locality)
+
+	zx := standardized(x)
+	zy := standardized(y)
+	p := make([]float64, len(x))
+	var (
+		neighbors []int
+		weights   []float64
+	)
+	for i := range zx {
+		neighbors = neighbors[:0]
+		weights = weights[:0]
+		for j := range zy {
+			if w := locality.At(i, j); j != i && w != 0 {
+				neighbors = append(neighbors, j)
+				weights = append(weights, w)
+			}
+		}
+		wii := locality.At(i, i)
+		p[i] = ConditionalPermute(i, zy, neighbors, func(self float64, values []float64) float64 {
+			return zx[i] * (wii*self + floats.Dot(weights, values))
+		}, perms, src)
+	}
+	return p
+}
+
+// BivariateMoransI returns the global bivariate Moran's I for x and y over
+// the given locality with its variance under the randomization assumption and
+// the corresponding z-score. The statistic is
+//  I = \sum_{i≠j} w_ij zx_i zy_j / S_0
+// where zx and zy are the standardized values of x and y and S_0 is the sum
+// of the off-diagonal weights. Positive values indicate that high (or low)
+// values of x are surrounded by high (or low) values of y. The diagonal of
+// the locality is ignored.
+//
+// The statistic is not symmetric in x and y: it relates x at each unit to the
+// spatial lag of y, so exchanging x and y will in general give a different
+// result unless the locality is symmetric.
+//
+// The moments are the exact moments of the statistic when the (x_i, y_i)
+// pairs are randomly permuted over the units. Under this assumption the
+// expected value is -r/(n-1), where r is the correlation of x and y, rather
+// than the -1/(n-1) of the univariate statistic.
+//
+// The locality must be square with dimensions matching the lengths of x and
+// y, otherwise BivariateMoransI will panic. The variance is only defined for
+// more than three units.
+func BivariateMoransI(x, y []float64, locality mat.Matrix) (i, v, z float64) {
+	if len(x) != len(y) {
+		panic("spatial: data length mismatch")
+	}
+	checkLocality(len(x), locality)
+
+	zx := standardized(x)
+	zy := standardized(y)
+	n := len(x)
+	cross := mat.NewDense(n, n, nil)
+	for p, a := range zx {
+		for q, b := range zy {
+			if p != q {
+				cross.Set(p, q, a*b)
+			}
+		}
+	}
+
+	var num float64
+	for p := 0; p < n; p++ {
+		for q := 0; q < n; q++ {
+			if p != q {
+				num += locality.At(p, q) * cross.At(p, q)
+			}
+		}
+	}
+	ws := offDiagonalSumsOf(locality)
+	cs := offDiagonalSumsOf(cross)
+
+	nf := float64(n)
+	n2 := nf * (nf - 1)
+	n3 := n2 * (nf - 2)
+	n4 := n3 * (nf - 3)
+	e := ws.total * cs.total / n2
+	e2 := (ws.sq*cs.sq+ws.cross*cs.cross)/n2 +
+		(ws.rowRow*cs.rowRow+ws.colCol*cs.colCol+2*ws.chain*cs.chain)/n3 +
+		ws.distinct*cs.distinct/n4
+
+	s0 := ws.total
+	i = num / s0
+	v = (e2 - e*e) / (s0 * s0)
+	return i, v, (i - e/s0) / math.Sqrt(v)
+}
+
+// offDiagonalSums holds the sums over pairs of off-diagonal elements of a
+// square matrix m grouped by the indices the elements share, from which the
+// moments of \sum_{i≠j} w_ij m_{π(i)π(j)} over random permutations π are
+// obtained.
+type offDiagonalSums struct {
+	// total is \sum_{i≠j} m_ij.
+	total float64
+
+	// sq is \sum_{i≠j} m_ij^2 and cross
+	// is \sum_{i≠j} m_ij m_ji.
+	sq, cross float64
+
+	// rowRow, colCol and chain are the sums
+	// over distinct i, j and k of m_ij m_ik,
+	// m_ij m_kj and m_ij m_jk.
+	rowRow, colCol, chain float64
+
+	// distinct is the sum of m_ij m_kl over
+	// distinct i, j, k and l.
+	distinct float64
+}
+
+// offDiagonalSumsOf returns the offDiagonalSums of the square matrix m.
+func offDiagonalSumsOf(m mat.Matrix) offDiagonalSums {
+	n, _ := m.Dims()
+	var s offDiagonalSums
+	row := make([]float64, n)
+	col := make([]float64, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i == j {
+				continue
+			}
+			v := m.At(i, j)
+			s.total += v
+			s.sq += v * v
+			s.cross += v * m.At(j, i)
+			row[i] += v
+			col[j] += v
+		}
+	}
+	for i := 0; i < n; i++ {
+		s.rowRow += row[i] * row[i]
+		s.colCol += col[i] * col[i]
+		s.chain += row[i] * col[i]
+	}
+	s.rowRow -= s.sq
+	s.colCol -= s.sq
+	s.chain -= s.cross
+	s.distinct = s.total*s.total - s.sq - s.cross - s.rowRow - s.colCol - 2*s.chain
+	return s
+}
+
+// standardized returns the values of data standardized to zero mean and
+// unit population standard deviation.
+func standardized(data []float64) []float64 {
+	n := float64(len(data))
+	mean := floats.Sum(data) / n
+	z := make([]float64, len(data))
+	var ss float64
+	for i, v := range data {
+		z[i] = v - mean
+		ss += z[i] * z[i]
+	}
+	floats.Scale(1/math.Sqrt(ss/n), z)
+	return z
+}
@@ -0,0 +1,95 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestBivariateLocalMoran(t *testing.T) {
+	locality := gridLocality(4, 4)
+	n, _ := locality.Dims()
+
+	// y is the spatial lag of x.
+	x := clusteredGrid
+	y := make([]float64, n)
+	mat.NewVector(n, y).MulVec(locality, mat.NewVector(n, x))
+
+	got := BivariateLocalMoran(x, y, locality)
+	high := []int{0, 1, 4, 5}
+	for _, i := range high {
+		for j := range got {
+			if x[j] < 7 && got[i] <= got[j] {
+				t.Errorf("local statistic at high unit %d not larger than at unit %d: %v <= %v", i, j, got[i], got[j])
+			}
+		}
+	}
+
+	if rev := BivariateLocalMoran(y, x, locality); floats.EqualApprox(got, rev, 1e-12) {
+		t.Errorf("unexpected symmetry in x and y: %v", got)
+	}
+
+	p := BivariateLocalMoranPValues(x, y, locality, 999, rand.NewSource(1))
+	for i, v := range p {
+		if v <= 0 || 1 < v {
+			t.Errorf("p-value out of range at unit %d: %v", i, v)
+		}
+	}
+	for _, i := range high {
+		if p[i] >= 0.05 {
+			t.Errorf("local statistic at high unit %d not significant: p=%v", i, p[i])
+		}
+	}
+}
+
+func TestBivariateMoransI(t *testing.T) {
+	// An asymmetric weighted locality on a 2×3 grid.
+	locality := gridLocality(2, 3)
+	locality.Set(0, 1, 2)
+	locality.Set(4, 5, 0.5)
+	locality.Set(2, 2, 3)
+	x := []float64{3, 1, 4, 1, 5, 9}
+	y := []float64{2, 7, 1, 8, 2, 8}
+
+	i, v, z := BivariateMoransI(x, y, locality)
+
+	n := len(x)
+	px := make([]float64, n)
+	py := make([]float64, n)
+	var count, mean, ss float64
+	permute(n, func(p []int) {
+		for k, j := range p {
+			px[k] = x[j]
+			py[k] = y[j]
+		}
+		s, _, _ := BivariateMoransI(px, py, locality)
+		count++
+		mean += s
+		ss += s * s
+	})
+	mean /= count
+	wantV := ss/count - mean*mean
+	if math.Abs(v-wantV) > 1e-12 {
+		t.Errorf("unexpected variance: got:%v want:%v", v, wantV)
+	}
+	if want := (i - mean) / math.Sqrt(wantV); math.Abs(z-want) > 1e-10 {
+		t.Errorf("unexpected z-score: got:%v want:%v", z, want)
+	}
+
+	// With y equal to x the statistic is Moran's I.
+	gotI, _, _ := BivariateMoransI(x, x, gridLocality(2, 3))
+	if wantI := moransI(x, gridLocality(2, 3)); math.Abs(gotI-wantI) > 1e-14 {
+		t.Errorf("unexpected univariate statistic: got:%v want:%v", gotI, wantI)
+	}
+
+	if rev, _, _ := BivariateMoransI(y, x, locality); math.Abs(rev-i) < 1e-12 {
+		t.Errorf("unexpected symmetry in x and y: %v", i)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"math/rand"
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat"
+)
+
+// BlockBootstrapCI returns the percentile bootstrap confidence interval at the
+// given level for Moran's I of data over the given locality, resampling the
+// spatial blocks of units in blocks to preserve spatial autocorrelation within
+// blocks. Each of the reps replicates fills every block with the values of a
+// block drawn with replacement, placing the values of the kth unit of the
+// drawn block at the kth unit of the filled block, and computes Moran's I of
+// the resulting data. The units of each block should therefore be listed in a
+// consistent spatial order. If src is nil, the global rand source is used.
+//
+// BlockBootstrapCI will panic if the blocks do not partition the units into
+// blocks of equal size, if reps is not positive, if level is not in (0, 1) or
+// if the locality is not square with dimensions matching the length of data.
+func BlockBootstrapCI(data []float64, locality mat.Matrix, blocks [][]int, reps int, level float64, src rand.Source) (lo, hi float64) {
+	checkLocality(len(data), locality)
+	if reps < 1 {
+		panic("spatial: invalid replicate count")
+	}
+	if !(0 < level && level < 1) {
+		panic("spatial: level out of range")
+	}
+	seen := make([]bool, len(data))
+	var count int
+	for _, b := range blocks {
+		if len(b) != len(blocks[0]) {
+			panic("spatial: unequal block sizes")
+		}
+		for _, i := range b {
+			if i < 0 || len(data) <= i || seen[i] {
+				panic("spatial: blocks do not partition units")
+			}
+			seen[i] = true
+			count++
+		}
+	}
+	if count != len(data) {
+		panic("spatial: blocks do not partition units")
+	}
+	intn := rand.Intn
+	if src != nil {
+		intn = rand.New(src).Intn
+	}
+
+	rep := make([]float64, len(data))
+	stats := make([]float64, reps)
+	for r := range stats {
+		for _, dst := range blocks {
+			from := blocks[intn(len(blocks))]
+			for k, i := range dst {
+				rep[i] = data[from[k]]
+			}
+		}
+		stats[r] = moransI(rep, locality)
+	}
+	sort.Float64s(stats)
+	tail := (1 - level) / 2
+	return stat.Quantile(tail, stat.Empirical, stats, nil), stat.Quantile(1-tail, stat.Empirical, stats, nil)
+}
@@ -0,0 +1,44 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestBlockBootstrapCI(t *testing.T) {
+	locality := gridLocality(4, 4)
+
+	// 2×2 blocks of the 4×4 grid with units listed in the
+	// same row-major order within each block.
+	blocks := [][]int{
+		{0, 1, 4, 5},
+		{2, 3, 6, 7},
+		{8, 9, 12, 13},
+		{10, 11, 14, 15},
+	}
+	lo, hi := BlockBootstrapCI(clusteredGrid, locality, blocks, 999, 0.9, rand.NewSource(1))
+	if lo >= hi {
+		t.Errorf("unexpected empty interval: [%v, %v]", lo, hi)
+	}
+	if againLo, againHi := BlockBootstrapCI(clusteredGrid, locality, blocks, 999, 0.9, rand.NewSource(1)); againLo != lo || againHi != hi {
+		t.Errorf("interval not stable for fixed seed: got:[%v, %v] want:[%v, %v]", againLo, againHi, lo, hi)
+	}
+
+	// The naive bootstrap resamples single units and so
+	// destroys the spatial structure of the data.
+	naive := make([][]int, len(clusteredGrid))
+	for i := range naive {
+		naive[i] = []int{i}
+	}
+	naiveLo, naiveHi := BlockBootstrapCI(clusteredGrid, locality, naive, 999, 0.9, rand.NewSource(1))
+	if lo <= naiveLo || hi <= naiveHi {
+		t.Errorf("block bootstrap interval not above naive interval: block:[%v, %v] naive:[%v, %v]", lo, hi, naiveLo, naiveHi)
+	}
+	if i := moransI(clusteredGrid, locality); i < lo || hi < i {
+		t.Errorf("observed statistic %v not in block bootstrap interval [%v, %v]", i, lo, hi)
+	}
+}
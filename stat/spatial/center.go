@@ -0,0 +1,114 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+// MeanCenter returns the weighted mean center of the points held in the rows
+// of coords. If weights is nil all points are given unit weight, otherwise
+// len(weights) must equal the number of rows in coords.
+func MeanCenter(coords mat.Matrix, weights []float64) []float64 {
+	r, c := coords.Dims()
+	if weights != nil && len(weights) != r {
+		panic("spatial: weights length mismatch")
+	}
+
+	center := make([]float64, c)
+	var sumWeights float64
+	for i := 0; i < r; i++ {
+		w := 1.0
+		if weights != nil {
+			w = weights[i]
+		}
+		for j := range center {
+			center[j] += w * coords.At(i, j)
+		}
+		sumWeights += w
+	}
+	floats.Scale(1/sumWeights, center)
+	return center
+}
+
+// MedianCenter returns the weighted spatial median of the points held in the
+// rows of coords, the point that minimizes the weighted sum of Euclidean
+// distances to all the points. If weights is nil all points are given unit
+// weight, otherwise len(weights) must equal the number of rows in coords.
+//
+// MedianCenter uses Weiszfeld's algorithm starting from the weighted mean
+// center and iterates until the estimate moves less than tol, which must
+// be positive. When the estimate coincides with one of the points, the
+// modification of Vardi and Zhang is used to step away from, or settle on,
+// that point.
+//
+// Unlike the mean center, the median center is robust to outlying points.
+func MedianCenter(coords mat.Matrix, weights []float64, tol float64) []float64 {
+	r, c := coords.Dims()
+	if weights != nil && len(weights) != r {
+		panic("spatial: weights length mismatch")
+	}
+	if tol <= 0 {
+		panic("spatial: non-positive tolerance")
+	}
+
+	y := MeanCenter(coords, weights)
+	var (
+		next = make([]float64, c)
+		diff = make([]float64, c)
+		pull = make([]float64, c)
+	)
+	for {
+		for j := range next {
+			next[j] = 0
+			pull[j] = 0
+		}
+		var sumInvDist, eta float64
+		for i := 0; i < r; i++ {
+			w := 1.0
+			if weights != nil {
+				w = weights[i]
+			}
+			for j := range diff {
+				diff[j] = coords.At(i, j) - y[j]
+			}
+			d := floats.Norm(diff, 2)
+			if d == 0 {
+				eta += w
+				continue
+			}
+			for j := range next {
+				next[j] += w * coords.At(i, j) / d
+				pull[j] += w * diff[j] / d
+			}
+			sumInvDist += w / d
+		}
+		if sumInvDist == 0 {
+			// All the weight is at y.
+			return y
+		}
+		floats.Scale(1/sumInvDist, next)
+
+		if eta != 0 {
+			// Vardi and Zhang step for an estimate lying on a point.
+			f := eta / floats.Norm(pull, 2)
+			if f >= 1 {
+				return y
+			}
+			for j := range next {
+				next[j] = (1-f)*next[j] + f*y[j]
+			}
+		}
+
+		moved := floats.Distance(next, y, 2)
+		copy(y, next)
+		if moved < tol || math.IsNaN(moved) {
+			return y
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestMeanCenter(t *testing.T) {
+	coords := mat.NewDense(3, 2, []float64{
+		0, 0,
+		2, 0,
+		0, 4,
+	})
+	for _, test := range []struct {
+		weights []float64
+		want    []float64
+	}{
+		{weights: nil, want: []float64{2.0 / 3, 4.0 / 3}},
+		{weights: []float64{2, 1, 1}, want: []float64{0.5, 1}},
+	} {
+		got := MeanCenter(coords, test.weights)
+		if !floats.EqualApprox(got, test.want, 1e-14) {
+			t.Errorf("unexpected mean center for weights %v: got:%v want:%v", test.weights, got, test.want)
+		}
+	}
+}
+
+func TestMedianCenter(t *testing.T) {
+	const tol = 1e-10
+
+	// The median of the vertices of a square is its center.
+	square := mat.NewDense(4, 2, []float64{
+		0, 0,
+		1, 0,
+		0, 1,
+		1, 1,
+	})
+	got := MedianCenter(square, nil, tol)
+	if want := []float64{0.5, 0.5}; !floats.EqualApprox(got, want, 1e-8) {
+		t.Errorf("unexpected median center for square: got:%v want:%v", got, want)
+	}
+
+	// A point carrying the majority of the weight is the median.
+	heavy := mat.NewDense(3, 2, []float64{
+		0, 0,
+		1, 0,
+		0, 1,
+	})
+	got = MedianCenter(heavy, []float64{5, 1, 1}, tol)
+	if want := []float64{0, 0}; !floats.EqualApprox(got, want, 1e-8) {
+		t.Errorf("unexpected median center for dominant point: got:%v want:%v", got, want)
+	}
+
+	// A single extreme outlier drags the mean center but not the median.
+	outlier := mat.NewDense(5, 2, []float64{
+		0, 0,
+		1, 0,
+		0, 1,
+		1, 1,
+		100, 100,
+	})
+	center := []float64{0.5, 0.5}
+	mean := MeanCenter(outlier, nil)
+	median := MedianCenter(outlier, nil, tol)
+	meanShift := floats.Distance(mean, center, 2)
+	medianShift := floats.Distance(median, center, 2)
+	if medianShift > 1 {
+		t.Errorf("median center unexpectedly affected by outlier: got:%v", median)
+	}
+	if medianShift*10 > meanShift {
+		t.Errorf("median center not more robust than mean center: median shift:%v mean shift:%v", medianShift, meanShift)
+	}
+}
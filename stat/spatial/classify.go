@@ -0,0 +1,159 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/stat"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// SignificanceClass is the significance classification of a statistic.
+type SignificanceClass int
+
+const (
+	// NotSignificant indicates that the statistic is not significant.
+	NotSignificant SignificanceClass = iota
+	// PositiveSignificant indicates that the statistic is significantly
+	// larger than its expectation.
+	PositiveSignificant
+	// NegativeSignificant indicates that the statistic is significantly
+	// smaller than its expectation.
+	NegativeSignificant
+)
+
+// Classify returns the significance classification of each of the z-scores
+// in z at the significance level alpha. A z-score is significant when its
+// two-sided normal p-value, 2Φ(-|z|), is less than alpha, and is classified
+// as positive or negative according to its sign. NaN z-scores, such as those
+// of units suppressed by RequireNeighbors, are not significant. Classify will
+// panic if alpha is not in (0, 1).
+func Classify(z []float64, alpha float64) []SignificanceClass {
+	if !(0 < alpha && alpha < 1) {
+		panic("spatial: alpha out of range")
+	}
+	class := make([]SignificanceClass, len(z))
+	for i, v := range z {
+		if math.IsNaN(v) || 2*distuv.UnitNormal.CDF(-math.Abs(v)) >= alpha {
+			continue
+		}
+		if v > 0 {
+			class[i] = PositiveSignificant
+		} else {
+			class[i] = NegativeSignificant
+		}
+	}
+	return class
+}
+
+// LISACluster is the cluster type of a unit given by its local indicator
+// of spatial association.
+type LISACluster int
+
+const (
+	// ClusterNone indicates that the local statistic is not significant.
+	ClusterNone LISACluster = iota
+	// ClusterHH indicates a high value surrounded by high values.
+	ClusterHH
+	// ClusterLL indicates a low value surrounded by low values.
+	ClusterLL
+	// ClusterHL indicates a high value surrounded by low values.
+	ClusterHL
+	// ClusterLH indicates a low value surrounded by high values.
+	ClusterLH
+)
+
+// HotspotClass is the hotspot classification of a unit.
+type HotspotClass int
+
+const (
+	// NoHotSpot indicates that the unit is neither a hot nor a cold spot.
+	NoHotSpot HotspotClass = iota
+	// HotSpot indicates that the unit is a hot spot.
+	HotSpot
+	// ColdSpot indicates that the unit is a cold spot.
+	ColdSpot
+)
+
+// QuantileHotspots returns the hotspot classification of each of the scores
+// in gstar using their empirical quantiles. Units with scores no greater than
+// the lowerQ empirical quantile are cold spots, units with scores greater
+// than the upperQ empirical quantile are hot spots and the remaining units
+// are neither. The empirical quantiles are those returned by stat.Quantile
+// with stat.Empirical. The classification depends only on the ranks of the
+// scores and so makes no assumption about their distribution.
+//
+// QuantileHotspots will panic if lowerQ and upperQ are not in [0, 1] or if
+// lowerQ is greater than upperQ.
+func QuantileHotspots(gstar []float64, lowerQ, upperQ float64) []HotspotClass {
+	if !(0 <= lowerQ && lowerQ <= upperQ && upperQ <= 1) {
+		panic("spatial: quantile out of range")
+	}
+	class := make([]HotspotClass, len(gstar))
+	if len(gstar) == 0 {
+		return class
+	}
+	sorted := make([]float64, len(gstar))
+	copy(sorted, gstar)
+	sort.Float64s(sorted)
+	lo := stat.Quantile(lowerQ, stat.Empirical, sorted, nil)
+	hi := stat.Quantile(upperQ, stat.Empirical, sorted, nil)
+	for i, v := range gstar {
+		switch {
+		case v > hi:
+			class[i] = HotSpot
+		case v <= lo:
+			class[i] = ColdSpot
+		}
+	}
+	return class
+}
+
+// FalseDiscoveryRate returns which of the p-values in p are significant when
+// the false discovery rate is controlled at level alpha using the step-up
+// procedure of Benjamini and Hochberg (1995). With the m non-NaN p-values
+// sorted in ascending order, p_(1) <= ... <= p_(m), the p-values
+// p_(1), ..., p_(k) are significant where k is the largest rank with
+//  p_(k) <= k alpha / m.
+// The procedure does not depend on the statistic that produced the p-values.
+// NaN p-values are not significant and are not counted in m.
+// FalseDiscoveryRate will panic if alpha is not in (0, 1).
+func FalseDiscoveryRate(p []float64, alpha float64) []bool {
+	if !(0 < alpha && alpha < 1) {
+		panic("spatial: alpha out of range")
+	}
+	idx := make([]int, 0, len(p))
+	for i, v := range p {
+		if !math.IsNaN(v) {
+			idx = append(idx, i)
+		}
+	}
+	sort.Sort(byPValue{idx: idx, p: p})
+
+	m := float64(len(idx))
+	k := 0
+	for r, i := range idx {
+		if p[i] <= float64(r+1)*alpha/m {
+			k = r + 1
+		}
+	}
+	sig := make([]bool, len(p))
+	for _, i := range idx[:k] {
+		sig[i] = true
+	}
+	return sig
+}
+
+// byPValue sorts indices into p by their p-value.
+type byPValue struct {
+	idx []int
+	p   []float64
+}
+
+func (b byPValue) Len() int           { return len(b.idx) }
+func (b byPValue) Less(i, j int) bool { return b.p[b.idx[i]] < b.p[b.idx[j]] }
+func (b byPValue) Swap(i, j int)      { b.idx[i], b.idx[j] = b.idx[j], b.idx[i] }
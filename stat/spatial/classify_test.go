@@ -0,0 +1,80 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+	z := []float64{-3.2, -1.97, -1.95, -0.5, 0, 0.8, 1.95, 1.97, 4.1}
+	got := Classify(z, 0.05)
+	want := []SignificanceClass{
+		NegativeSignificant, NegativeSignificant, NotSignificant,
+		NotSignificant, NotSignificant, NotSignificant,
+		NotSignificant, PositiveSignificant, PositiveSignificant,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected classification: got:%v want:%v", got, want)
+	}
+}
+
+func TestQuantileHotspots(t *testing.T) {
+	gstar := []float64{0.3, -2.1, 1.4, 3.2, -0.4, 0.9, -1.2, 2.5, 0.1, -0.8}
+	got := QuantileHotspots(gstar, 0.1, 0.9)
+	want := make([]HotspotClass, len(gstar))
+	want[1] = ColdSpot
+	want[3] = HotSpot
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected classification: got:%v want:%v", got, want)
+	}
+
+	// The hot spot of the clustered example data is in its
+	// high-valued corner, and the cold spots, including two
+	// units tied at the lower quantile, are in the low-valued
+	// corner.
+	locality := gridLocality(4, 4)
+	for i := 0; i < 16; i++ {
+		locality.Set(i, i, 1)
+	}
+	_, gstar = GandGstarAll(clusteredGrid, locality)
+	got = QuantileHotspots(gstar, 0.1, 0.9)
+	want = make([]HotspotClass, len(gstar))
+	want[0] = HotSpot
+	want[10] = ColdSpot
+	want[11] = ColdSpot
+	want[14] = ColdSpot
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected classification of clustered data: got:%v want:%v", got, want)
+	}
+}
+
+func TestFalseDiscoveryRate(t *testing.T) {
+	// The worked example from Benjamini and Hochberg (1995),
+	// where four of the fifteen hypotheses are rejected at
+	// q = 0.05, presented out of order with a missing value.
+	p := []float64{
+		0.0298, 0.0001, 0.7590, 0.0344, 0.0095,
+		0.3240, 0.0004, 1, 0.0459, math.NaN(),
+		0.4262, 0.0019, 0.5719, 0.0201, 0.6528, 0.0278,
+	}
+	got := FalseDiscoveryRate(p, 0.05)
+	want := make([]bool, len(p))
+	for _, i := range []int{1, 4, 6, 11} {
+		want[i] = true
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected significance: got:%v want:%v", got, want)
+	}
+
+	// A p-value above its own threshold is significant when a
+	// larger p-value meets its threshold.
+	got = FalseDiscoveryRate([]float64{0.065, 0.06, 0.5}, 0.1)
+	if want := []bool{true, true, false}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected step-up significance: got:%v want:%v", got, want)
+	}
+}
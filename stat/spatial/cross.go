@@ -0,0 +1,75 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// SpatialCrossCorrelation returns the spatial cross-correlation of x with
+// the lagged values of y for each of the distance bands defined by lags, for
+// the points held in the rows of coords. The lags are the increasing upper
+// bounds of the bands, so band k holds the pairs of distinct points with
+// distance in (lags[k-1], lags[k]], with the lower bound of the first band
+// being zero. The cross-correlation for a band is
+//  r_k = \sum_{i≠j} w_ij zx_i zy_j / \sum_{i≠j} w_ij
+// where w_ij is one for pairs in the band and zero otherwise, and zx and zy
+// are the standardized values of x and y. With x and y the same variable,
+// the cross-correlations form a correlogram. The cross-correlation is not
+// symmetric in x and y. A band holding no pairs gives a NaN value.
+//
+// SpatialCrossCorrelation will panic if the lengths of x and y do not match
+// the number of points or if lags is not strictly increasing and positive.
+func SpatialCrossCorrelation(x, y []float64, coords mat.Matrix, lags []float64) []float64 {
+	n, _ := coords.Dims()
+	if len(x) != n || len(y) != n {
+		panic("spatial: data length mismatch")
+	}
+	for k, l := range lags {
+		if l <= 0 || (k > 0 && l <= lags[k-1]) {
+			panic("spatial: invalid lags")
+		}
+	}
+
+	zx := standardized(x)
+	zy := standardized(y)
+	dist := distances(coords)
+	sum := make([]float64, len(lags))
+	count := make([]float64, len(lags))
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i == j {
+				continue
+			}
+			k := band(dist.At(i, j), lags)
+			if k < 0 {
+				continue
+			}
+			sum[k] += zx[i] * zy[j]
+			count[k]++
+		}
+	}
+	for k, c := range count {
+		if c == 0 {
+			sum[k] = math.NaN()
+			continue
+		}
+		sum[k] /= c
+	}
+	return sum
+}
+
+// band returns the index of the distance band holding d, or -1 if d is
+// beyond the last band.
+func band(d float64, lags []float64) int {
+	for k, l := range lags {
+		if d <= l {
+			return k
+		}
+	}
+	return -1
+}
@@ -0,0 +1,48 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestSpatialCrossCorrelation(t *testing.T) {
+	// Points on a transect with y_j = x_{j-shift}.
+	const (
+		n     = 80
+		shift = 3
+	)
+	rnd := rand.New(rand.NewSource(1))
+	raw := make([]float64, n+shift)
+	for i := range raw {
+		raw[i] = rnd.NormFloat64()
+	}
+	x := raw[shift:]
+	y := raw[:n]
+	coords := mat.NewDense(n, 1, nil)
+	for i := 0; i < n; i++ {
+		coords.Set(i, 0, float64(i))
+	}
+
+	lags := []float64{1.5, 2.5, 3.5, 4.5, 5.5}
+	got := SpatialCrossCorrelation(x, y, coords, lags)
+	if peak := floats.MaxIdx(got); peak != shift-1 {
+		t.Errorf("unexpected cross-correlation peak band: got:%d want:%d values:%v", peak, shift-1, got)
+	}
+
+	// The correlogram of x with itself peaks at the shortest lag
+	// for smoothly varying data.
+	smooth := make([]float64, n)
+	for i := range smooth {
+		smooth[i] = floats.Sum(raw[i : i+shift+1])
+	}
+	if peak := floats.MaxIdx(SpatialCrossCorrelation(smooth, smooth, coords, lags)); peak != 0 {
+		t.Errorf("unexpected correlogram peak band: got:%d want:0", peak)
+	}
+}
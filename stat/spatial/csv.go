@@ -0,0 +1,63 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ReadSpatialCSV reads comma-separated numeric data from r and returns its
+// columns keyed by the names given in the header line. The rows are in
+// the order of the units of the locality the data are to be analyzed with,
+// so each column may be passed to the spatial statistics functions.
+//
+// ReadSpatialCSV returns an error if the header is missing or holds a
+// repeated name, if a row does not have the same number of fields as the
+// header, or if a cell is not a number. Errors relating to a cell report the
+// line of the input it starts on, which accounts for quoted fields spanning
+// several lines.
+func ReadSpatialCSV(r io.Reader) (columns map[string][]float64, err error) {
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, errors.New("spatial: missing header")
+	}
+	if err != nil {
+		return nil, err
+	}
+	columns = make(map[string][]float64, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		if _, exists := columns[name]; exists {
+			return nil, fmt.Errorf("spatial: repeated column name %q", name)
+		}
+		header[i] = name
+		columns[name] = nil
+	}
+
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		for i, cell := range record {
+			v, err := strconv.ParseFloat(strings.TrimSpace(cell), 64)
+			if err != nil {
+				line, _ := cr.FieldPos(i)
+				return nil, fmt.Errorf("spatial: line %d: column %q: invalid number %q", line, header[i], cell)
+			}
+			columns[header[i]] = append(columns[header[i]], v)
+		}
+	}
+	return columns, nil
+}
@@ -0,0 +1,49 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadSpatialCSV(t *testing.T) {
+	const data = `income, crime,density
+31.5,1.2,100
+22,4.5,250
+40.25, 0.5,80
+`
+	got, err := ReadSpatialCSV(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string][]float64{
+		"income":  {31.5, 22, 40.25},
+		"crime":   {1.2, 4.5, 0.5},
+		"density": {100, 250, 80},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected columns: got:%v want:%v", got, want)
+	}
+
+	for _, test := range []struct {
+		data string
+		want string
+	}{
+		{data: "", want: "spatial: missing header"},
+		{data: "a,a\n1,2\n", want: `spatial: repeated column name "a"`},
+		{data: "a,b\n1,2\n3,x\n", want: `spatial: line 3: column "b": invalid number "x"`},
+		{data: "a,b\n\"1\n\",2\n3,x\n", want: `spatial: line 4: column "b": invalid number "x"`},
+		{data: "a,b\n1,2\n\n3,x\n", want: `spatial: line 4: column "b": invalid number "x"`},
+		{data: "a,b\n1,\"2\nx\"\n", want: `spatial: line 2: column "b": invalid number "2\nx"`},
+		{data: "a,b\n1,2\n3\n", want: "wrong number of fields"},
+	} {
+		_, err := ReadSpatialCSV(strings.NewReader(test.data))
+		if err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("unexpected error for %q: got:%v want:%s", test.data, err, test.want)
+		}
+	}
+}
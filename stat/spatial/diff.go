@@ -0,0 +1,64 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+// DiffMoment returns the weighted sum of squared neighbor differences of data
+// over the given locality,
+//  stat = \sum_i \sum_j w_ij (x_i - x_j)^2,
+// and its z-score under the randomization assumption. The statistic is the
+// unnormalized numerator of Geary's C and is a continuous analog of the
+// black-white join count; large values indicate that neighboring units have
+// dissimilar values and small values that they have similar values.
+//
+// The locality must be square with dimensions matching the length of data,
+// otherwise DiffMoment will panic. The z-score is only defined for more than
+// three units.
+func DiffMoment(data []float64, locality mat.Matrix) (stat, z float64) {
+	checkLocality(len(data), locality)
+	n := float64(len(data))
+
+	mean := floats.Sum(data) / n
+	var m2, m4 float64
+	for _, v := range data {
+		d := v - mean
+		d2 := d * d
+		m2 += d2
+		m4 += d2 * d2
+	}
+
+	var s0, s1, s2 float64
+	for i, xi := range data {
+		var row, col float64
+		for j, xj := range data {
+			w := locality.At(i, j)
+			d := xi - xj
+			stat += w * d * d
+			s0 += w
+			s := w + locality.At(j, i)
+			s1 += s * s
+			row += w
+			col += locality.At(j, i)
+		}
+		s2 += (row + col) * (row + col)
+	}
+	s1 /= 2
+
+	// The permutation distribution of the statistic is a scaling of
+	// that of Geary's C, which has unit mean under randomization.
+	k := n * m4 / (m2 * m2)
+	c := (n - 1) * stat / (2 * s0 * m2)
+	v := ((n-1)*s1*(n*n-3*n+3-(n-1)*k) -
+		(n-1)*s2*(n*n+3*n-6-(n*n-n+2)*k)/4 +
+		s0*s0*(n*n-3-(n-1)*(n-1)*k)) /
+		(n * (n - 2) * (n - 3) * s0 * s0)
+	return stat, (c - 1) / math.Sqrt(v)
+}
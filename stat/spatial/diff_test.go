@@ -0,0 +1,97 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestDiffMoment(t *testing.T) {
+	locality := gridLocality(4, 4)
+
+	checker := []float64{
+		5.1, 0.2, 4.8, 0.1,
+		0.3, 5.2, 0.1, 4.9,
+		4.7, 0.2, 5.0, 0.4,
+		0.1, 4.9, 0.3, 5.3,
+	}
+	hiStat, hiZ := DiffMoment(checker, locality)
+	smooth := []float64{
+		0.1, 0.8, 1.6, 2.4,
+		0.9, 1.5, 2.3, 3.1,
+		1.7, 2.4, 3.2, 3.9,
+		2.5, 3.3, 3.8, 4.6,
+	}
+	loStat, loZ := DiffMoment(smooth, locality)
+	if hiZ < 1.96 {
+		t.Errorf("checkerboard field not significantly dissimilar: stat=%v z=%v", hiStat, hiZ)
+	}
+	if loZ > -1.96 {
+		t.Errorf("smooth field not significantly similar: stat=%v z=%v", loStat, loZ)
+	}
+	if hiStat <= loStat {
+		t.Errorf("checkerboard statistic not larger than smooth statistic: %v <= %v", hiStat, loStat)
+	}
+}
+
+func TestDiffMomentRandomization(t *testing.T) {
+	// Enumerate all permutations of the data to obtain the exact
+	// randomization mean and variance of the statistic.
+	locality := mat.NewDense(6, 6, []float64{
+		0, 1, 0, 1, 0, 0,
+		1, 0, 1, 0, 2, 0,
+		0, 1, 0, 0, 0, 1,
+		1, 0, 0, 0, 1, 0,
+		0, 0.5, 0, 1, 0, 1,
+		0, 0, 1, 0, 1, 0,
+	})
+	data := []float64{3, 0.5, 1, 7, 2, 2.5}
+	stat, z := DiffMoment(data, locality)
+
+	var sum, sumSq, count float64
+	perm := make([]float64, len(data))
+	permute(len(data), func(p []int) {
+		for k, j := range p {
+			perm[k] = data[j]
+		}
+		s, _ := DiffMoment(perm, locality)
+		sum += s
+		sumSq += s * s
+		count++
+	})
+	mean := sum / count
+	want := (stat - mean) / math.Sqrt(sumSq/count-mean*mean)
+	if !floats.EqualWithinAbsOrRel(z, want, 1e-10, 1e-10) {
+		t.Errorf("unexpected z-score: got:%v want:%v", z, want)
+	}
+}
+
+// permute calls fn with each permutation of [0, n).
+func permute(n int, fn func([]int)) {
+	p := make([]int, n)
+	for i := range p {
+		p[i] = i
+	}
+	var gen func(k int)
+	gen = func(k int) {
+		if k == 1 {
+			fn(p)
+			return
+		}
+		for i := 0; i < k; i++ {
+			gen(k - 1)
+			if k%2 == 0 {
+				p[i], p[k-1] = p[k-1], p[i]
+			} else {
+				p[0], p[k-1] = p[k-1], p[0]
+			}
+		}
+	}
+	gen(n)
+}
@@ -0,0 +1,72 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// GeneralG returns the Getis-Ord General G statistic for data over the given
+// locality with its variance and z-score under the randomization assumption,
+//  G = \sum_{i≠j} w_ij x_i x_j / \sum_{i≠j} x_i x_j.
+// The diagonal of the locality is ignored. Values of G larger than their
+// expectation indicate clustering of high values and values smaller than
+// their expectation indicate clustering of low values. The moments are those
+// given by Getis and Ord (1992).
+//
+// The data must be non-negative, and the locality must be square with
+// dimensions matching the length of data, otherwise GeneralG will panic. The
+// variance and z-score are only defined for more than three units.
+func GeneralG(data []float64, locality mat.Matrix) (g, v, z float64) {
+	checkLocality(len(data), locality)
+	n := float64(len(data))
+
+	var m1, m2, m3, m4 float64
+	for _, v := range data {
+		if v < 0 {
+			panic("spatial: negative data")
+		}
+		v2 := v * v
+		m1 += v
+		m2 += v2
+		m3 += v2 * v
+		m4 += v2 * v2
+	}
+
+	var num, s0, s1, s2 float64
+	for i, xi := range data {
+		var row, col float64
+		for j, xj := range data {
+			if i == j {
+				continue
+			}
+			w := locality.At(i, j)
+			num += w * xi * xj
+			s0 += w
+			s := w + locality.At(j, i)
+			s1 += s * s
+			row += w
+			col += locality.At(j, i)
+		}
+		s2 += (row + col) * (row + col)
+	}
+	s1 /= 2
+	g = num / (m1*m1 - m2)
+
+	w2 := s0 * s0
+	b0 := (n*n-3*n+3)*s1 - n*s2 + 3*w2
+	b1 := -((n*n-n)*s1 - 2*n*s2 + 6*w2)
+	b2 := -(2*n*s1 - (n+3)*s2 + 6*w2)
+	b3 := 4*(n-1)*s1 - 2*(n+1)*s2 + 8*w2
+	b4 := s1 - s2 + w2
+	d := m1*m1 - m2
+	e := s0 / (n * (n - 1))
+	e2 := (b0*m2*m2 + b1*m4 + b2*m1*m1*m2 + b3*m1*m3 + b4*m1*m1*m1*m1) /
+		(d * d * n * (n - 1) * (n - 2) * (n - 3))
+	v = e2 - e*e
+	return g, v, (g - e) / math.Sqrt(v)
+}
@@ -0,0 +1,32 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial_test
+
+import (
+	"fmt"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat/spatial"
+)
+
+func ExampleGeneralG() {
+	// Eight units along a line with high values
+	// clustered at one end.
+	data := []float64{9, 8, 9, 7, 2, 1, 2, 1}
+	locality := mat.NewDense(len(data), len(data), nil)
+	for i := 0; i < len(data)-1; i++ {
+		locality.Set(i, i+1, 1)
+		locality.Set(i+1, i, 1)
+	}
+
+	// The expected value of G under randomization is
+	// S0 / (n(n-1)) = 14/56 = 0.25, so the positive
+	// z-score indicates clustering of high values.
+	g, v, z := spatial.GeneralG(data, locality)
+	fmt.Printf("G = %.4f Var[G] = %.5f z = %.4f\n", g, v, z)
+
+	// Output:
+	// G = 0.3673 Var[G] = 0.00371 z = 1.9263
+}
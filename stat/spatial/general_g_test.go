@@ -0,0 +1,69 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestGeneralG(t *testing.T) {
+	locality := gridLocality(4, 4)
+
+	g, _, z := GeneralG(clusteredGrid, locality)
+	if g <= 0 || z < 1.96 {
+		t.Errorf("clustered data not significantly clustered: G=%v z=%v", g, z)
+	}
+
+	dispersed := []float64{
+		2, 5, 1, 4,
+		3, 1, 4, 2,
+		5, 3, 2, 3,
+		1, 4, 3, 5,
+	}
+	g, _, z = GeneralG(dispersed, locality)
+	if math.Abs(z) >= 1.96 {
+		t.Errorf("dispersed data unexpectedly significant: G=%v z=%v", g, z)
+	}
+}
+
+func TestGeneralGRandomization(t *testing.T) {
+	// Enumerate all permutations of the data to obtain the exact
+	// randomization mean and variance of the statistic.
+	locality := mat.NewDense(6, 6, []float64{
+		1, 1, 0, 1, 0, 0,
+		1, 0, 1, 0, 2, 0,
+		0, 1, 0, 0, 0, 1,
+		1, 0, 0, 0, 1, 0,
+		0, 0.5, 0, 1, 0, 1,
+		0, 0, 1, 0, 1, 3,
+	})
+	data := []float64{3, 0.5, 1, 7, 2, 2.5}
+	g, v, z := GeneralG(data, locality)
+
+	var sum, sumSq, count float64
+	perm := make([]float64, len(data))
+	permute(len(data), func(p []int) {
+		for k, j := range p {
+			perm[k] = data[j]
+		}
+		s, _, _ := GeneralG(perm, locality)
+		sum += s
+		sumSq += s * s
+		count++
+	})
+	mean := sum / count
+	wantV := sumSq/count - mean*mean
+	if !floats.EqualWithinAbsOrRel(v, wantV, 1e-10, 1e-10) {
+		t.Errorf("unexpected variance: got:%v want:%v", v, wantV)
+	}
+	want := (g - mean) / math.Sqrt(wantV)
+	if !floats.EqualWithinAbsOrRel(z, want, 1e-10, 1e-10) {
+		t.Errorf("unexpected z-score: got:%v want:%v", z, want)
+	}
+}
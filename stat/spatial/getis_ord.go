@@ -0,0 +1,98 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// GandGstarAll returns the standardized local Getis-Ord G_i and G*_i
+// statistics of Ord and Getis (1995) for each unit of data over the given
+// locality. G*_i includes the unit's own value and weight, the diagonal
+// element of the locality, in the weighted sum, and G_i excludes them. Both
+// are returned as z-scores; large positive values indicate clustering of high
+// values around the unit and large negative values clustering of low values.
+//
+// The two statistics have different null distributions. G*_i is standardized
+// using the mean and variance of all n values, while G_i is conditional on
+// x_i and is standardized using the mean and variance of the remaining n-1
+// values. The shared sums are computed once and the self contributions are
+// removed for each unit to obtain G_i.
+//
+// The statistics are undefined when the values they are standardized by
+// have zero variance, so if data is constant every G_i and G*_i is NaN, and
+// if all the values other than x_i are equal G_i is NaN.
+//
+// The locality must be square with dimensions matching the length of data,
+// otherwise GandGstarAll will panic.
+func GandGstarAll(data []float64, locality mat.Matrix) (g, gstar []float64) {
+	checkLocality(len(data), locality)
+	n := float64(len(data))
+
+	var sum, sumSq float64
+	for _, v := range data {
+		sum += v
+		sumSq += v * v
+	}
+	mean := sum / n
+	sd := math.Sqrt(sumSq/n - mean*mean)
+
+	// Find the extreme values and their counts to identify
+	// the units with constant remaining values.
+	lo, hi := math.Inf(1), math.Inf(-1)
+	var nLo, nHi int
+	for _, v := range data {
+		switch {
+		case v < lo:
+			lo, nLo = v, 1
+		case v == lo:
+			nLo++
+		}
+		switch {
+		case v > hi:
+			hi, nHi = v, 1
+		case v == hi:
+			nHi++
+		}
+	}
+
+	g = make([]float64, len(data))
+	gstar = make([]float64, len(data))
+	if lo == hi {
+		for i := range data {
+			g[i] = math.NaN()
+			gstar[i] = math.NaN()
+		}
+		return g, gstar
+	}
+	for i, xi := range data {
+		var lag, wSum, wSq float64
+		for j, xj := range data {
+			w := locality.At(i, j)
+			lag += w * xj
+			wSum += w
+			wSq += w * w
+		}
+		gstar[i] = (lag - mean*wSum) / (sd * math.Sqrt((n*wSq-wSum*wSum)/(n-1)))
+
+		if (xi == lo && nLo == 1 && nHi == len(data)-1) || (xi == hi && nHi == 1 && nLo == len(data)-1) {
+			g[i] = math.NaN()
+			continue
+		}
+
+		// Remove the contribution of unit i.
+		wii := locality.At(i, i)
+		lag -= wii * xi
+		wSum -= wii
+		wSq -= wii * wii
+		m := n - 1
+		meanI := (sum - xi) / m
+		sdI := math.Sqrt((sumSq-xi*xi)/m - meanI*meanI)
+		g[i] = (lag - meanI*wSum) / (sdI * math.Sqrt((m*wSq-wSum*wSum)/(m-1)))
+	}
+	return g, gstar
+}
@@ -0,0 +1,104 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestGandGstarAll(t *testing.T) {
+	locality := gridLocality(4, 4)
+	for i := 0; i < 16; i++ {
+		locality.Set(i, i, 1)
+	}
+	_, gstar := GandGstarAll(clusteredGrid, locality)
+	if gstar[0] < 1.96 {
+		t.Errorf("hot spot not detected: G*=%v", gstar[0])
+	}
+
+	// Constant values have zero variance, so the statistics
+	// are undefined.
+	constant := make([]float64, 16)
+	for i := range constant {
+		constant[i] = 0.1
+	}
+	g, gstar := GandGstarAll(constant, locality)
+	for i := range constant {
+		if !math.IsNaN(g[i]) || !math.IsNaN(gstar[i]) {
+			t.Errorf("unexpected statistics for constant data at unit %d: got:(%v, %v) want:(NaN, NaN)", i, g[i], gstar[i])
+		}
+	}
+	constant[3] = 2
+	g, gstar = GandGstarAll(constant, locality)
+	for i := range constant {
+		if math.IsNaN(gstar[i]) || math.IsInf(gstar[i], 0) {
+			t.Errorf("unexpected G* for unit %d with one distinct value: %v", i, gstar[i])
+		}
+		if got := math.IsNaN(g[i]); got != (i == 3) {
+			t.Errorf("unexpected G for unit %d with one distinct value: %v", i, g[i])
+		}
+	}
+}
+
+func TestGandGstarAllRandomization(t *testing.T) {
+	// Enumerate all permutations of the data to obtain the exact
+	// randomization mean and variance of the weighted sums. G*_i
+	// permutes all the values over the units, and G_i holds x_i
+	// fixed and permutes the remaining values over the other units.
+	locality := mat.NewDense(6, 6, []float64{
+		1, 1, 0, 1, 0, 0,
+		1, 2, 1, 0, 2, 0,
+		0, 1, 0, 0, 0, 1,
+		1, 0, 0, 1, 1, 0,
+		0, 0.5, 0, 1, 0.5, 1,
+		0, 0, 1, 0, 1, 3,
+	})
+	data := []float64{3, 0.5, 1, 7, 2, 2.5}
+	g, gstar := GandGstarAll(data, locality)
+
+	n := len(data)
+	for i := 0; i < n; i++ {
+		var star, cond moments
+		permute(n, func(p []int) {
+			var sum float64
+			for j, k := range p {
+				sum += locality.At(i, j) * data[k]
+			}
+			star.add(sum)
+			if p[i] == i {
+				cond.add(sum - locality.At(i, i)*data[i])
+			}
+		})
+		obsStar := floats.Dot(locality.RawRowView(i), data)
+		if want := star.z(obsStar); !floats.EqualWithinAbsOrRel(gstar[i], want, 1e-10, 1e-10) {
+			t.Errorf("unexpected G* at unit %d: got:%v want:%v", i, gstar[i], want)
+		}
+		obs := obsStar - locality.At(i, i)*data[i]
+		if want := cond.z(obs); !floats.EqualWithinAbsOrRel(g[i], want, 1e-10, 1e-10) {
+			t.Errorf("unexpected G at unit %d: got:%v want:%v", i, g[i], want)
+		}
+	}
+}
+
+// moments accumulates the mean and variance of a set of values.
+type moments struct {
+	sum, sumSq, count float64
+}
+
+func (m *moments) add(v float64) {
+	m.sum += v
+	m.sumSq += v * v
+	m.count++
+}
+
+// z returns the z-score of v relative to the accumulated values.
+func (m *moments) z(v float64) float64 {
+	mean := m.sum / m.count
+	return (v - mean) / math.Sqrt(m.sumSq/m.count-mean*mean)
+}
@@ -0,0 +1,128 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// JoinCount holds the join count statistics of binary data over a locality.
+// Units with value 1 are black and units with value 0 are white.
+type JoinCount struct {
+	bb, ww, bw float64
+
+	// p is the proportion of black units.
+	p float64
+
+	// s0, s1 and s2 are the locality
+	// sums of Cliff and Ord.
+	s0, s1, s2 float64
+}
+
+// NewJoinCount returns a JoinCount for the binary data over the given
+// locality, where the join counts are
+//  BB = 1/2 \sum_{i≠j} w_ij x_i x_j,
+//  WW = 1/2 \sum_{i≠j} w_ij (1-x_i)(1-x_j),
+//  BW = 1/2 \sum_{i≠j} w_ij (x_i-x_j)^2.
+// For a symmetric binary locality these are the numbers of joins between
+// pairs of black units, pairs of white units and black and white units. The
+// diagonal of the locality is ignored.
+//
+// The data must hold only 0 and 1 and the locality must be square with
+// dimensions matching the length of data, otherwise NewJoinCount will panic.
+func NewJoinCount(data []float64, locality mat.Matrix) *JoinCount {
+	checkLocality(len(data), locality)
+	var black int
+	for _, v := range data {
+		switch v {
+		case 0:
+		case 1:
+			black++
+		default:
+			panic("spatial: non-binary data")
+		}
+	}
+	jc := &JoinCount{p: float64(black) / float64(len(data))}
+
+	for i, xi := range data {
+		var row, col float64
+		for j, xj := range data {
+			if i == j {
+				continue
+			}
+			w := locality.At(i, j)
+			switch {
+			case xi == 1 && xj == 1:
+				jc.bb += w
+			case xi == 0 && xj == 0:
+				jc.ww += w
+			default:
+				jc.bw += w
+			}
+			jc.s0 += w
+			s := w + locality.At(j, i)
+			jc.s1 += s * s
+			row += w
+			col += locality.At(j, i)
+		}
+		jc.s2 += (row + col) * (row + col)
+	}
+	jc.bb /= 2
+	jc.ww /= 2
+	jc.bw /= 2
+	jc.s1 /= 2
+	return jc
+}
+
+// BB returns the number of black-black joins.
+func (jc *JoinCount) BB() float64 { return jc.bb }
+
+// WW returns the number of white-white joins.
+func (jc *JoinCount) WW() float64 { return jc.ww }
+
+// BW returns the number of black-white joins.
+func (jc *JoinCount) BW() float64 { return jc.bw }
+
+// ZBB returns the z-score of the number of black-black joins under the free
+// sampling assumption, where each unit is independently black with the
+// observed proportion of black units, p. The moments are
+//  E[BB] = S_0 p^2 / 2,
+//  Var[BB] = (S_1 p^2 + (S_2 - 2 S_1) p^3 + (S_1 - S_2) p^4) / 4,
+// as given by Cliff and Ord (1981). The z-score is NaN if all units have
+// the same color.
+func (jc *JoinCount) ZBB() float64 {
+	return jc.sameColorZ(jc.bb, jc.p)
+}
+
+// ZWW returns the z-score of the number of white-white joins under the free
+// sampling assumption. The moments are those of ZBB with the proportion of
+// white units, 1-p, in place of p.
+func (jc *JoinCount) ZWW() float64 {
+	return jc.sameColorZ(jc.ww, 1-jc.p)
+}
+
+// ZBW returns the z-score of the number of black-white joins under the free
+// sampling assumption. With q = 1-p the moments are
+//  E[BW] = S_0 p q,
+//  Var[BW] = (S_2 p q + 4 (S_1 - S_2) p^2 q^2) / 4.
+// Negative z-scores indicate positive spatial autocorrelation. The z-score
+// is NaN if all units have the same color.
+func (jc *JoinCount) ZBW() float64 {
+	pq := jc.p * (1 - jc.p)
+	e := jc.s0 * pq
+	v := (jc.s2*pq + 4*(jc.s1-jc.s2)*pq*pq) / 4
+	return (jc.bw - e) / math.Sqrt(v)
+}
+
+// sameColorZ returns the z-score of the same color join count c where p is
+// the proportion of units of the color.
+func (jc *JoinCount) sameColorZ(c, p float64) float64 {
+	p2 := p * p
+	e := jc.s0 * p2 / 2
+	v := (jc.s1*p2 + (jc.s2-2*jc.s1)*p2*p + (jc.s1-jc.s2)*p2*p2) / 4
+	return (c - e) / math.Sqrt(v)
+}
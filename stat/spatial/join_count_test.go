@@ -0,0 +1,79 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"math"
+	"testing"
+)
+
+func TestJoinCount(t *testing.T) {
+	// A 2×4 rook grid with the top row black has
+	// 3 black-black, 3 white-white and 4 black-white joins.
+	data := []float64{
+		1, 1, 1, 1,
+		0, 0, 0, 0,
+	}
+	locality := gridLocality(2, 4)
+	jc := NewJoinCount(data, locality)
+	if jc.BB() != 3 || jc.WW() != 3 || jc.BW() != 4 {
+		t.Errorf("unexpected join counts: got BB:%v WW:%v BW:%v want BB:3 WW:3 BW:4", jc.BB(), jc.WW(), jc.BW())
+	}
+	if !(jc.ZBB() > 0 && jc.ZWW() > 0 && jc.ZBW() < 0) {
+		t.Errorf("unexpected z-scores for clustered data: BB:%v WW:%v BW:%v", jc.ZBB(), jc.ZWW(), jc.ZBW())
+	}
+
+	// Check the free sampling moments against enumeration
+	// of all colorings with an asymmetric weighted locality.
+	locality.Set(0, 1, 2)
+	locality.Set(5, 6, 0.5)
+	data = []float64{
+		1, 0, 0, 1,
+		0, 1, 0, 0,
+	}
+	const p = 3.0 / 8
+	jc = NewJoinCount(data, locality)
+
+	var mean, ss [3]float64
+	x := make([]float64, len(data))
+	for mask := 0; mask < 1<<uint(len(data)); mask++ {
+		prob := 1.0
+		for i := range x {
+			x[i] = float64(mask >> uint(i) & 1)
+			if x[i] == 1 {
+				prob *= p
+			} else {
+				prob *= 1 - p
+			}
+		}
+		c := NewJoinCount(x, locality)
+		for k, v := range []float64{c.BB(), c.WW(), c.BW()} {
+			mean[k] += prob * v
+			ss[k] += prob * v * v
+		}
+	}
+	for k, test := range []struct {
+		name     string
+		count, z float64
+	}{
+		{name: "BB", count: jc.BB(), z: jc.ZBB()},
+		{name: "WW", count: jc.WW(), z: jc.ZWW()},
+		{name: "BW", count: jc.BW(), z: jc.ZBW()},
+	} {
+		want := (test.count - mean[k]) / math.Sqrt(ss[k]-mean[k]*mean[k])
+		if math.Abs(test.z-want) > 1e-12 {
+			t.Errorf("unexpected %s z-score: got:%v want:%v", test.name, test.z, want)
+		}
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected panic for non-binary data")
+			}
+		}()
+		NewJoinCount([]float64{0, 1, 0.5, 1, 0, 0, 1, 0}, locality)
+	}()
+}
@@ -0,0 +1,166 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+// ApproxWeightsEigenvalues returns approximations to the k eigenvalues of
+// largest magnitude of the symmetrized locality, (W + Wᵀ)/2, sorted in
+// descending order. The approximations are the extremal Ritz values of a
+// Lanczos iteration with full reorthogonalization that uses only products
+// of the locality with vectors. The non-zero weights are collected in a
+// single O(n²) pass over the locality, after which each of the m Lanczos
+// steps costs O(z) product work for z non-zero weights and O(mn)
+// reorthogonalization work, giving a total of O(n² + mz + m²n) rather than
+// the O(n³) of the full decomposition computed by WeightsEigenvalues. For
+// the sparse localities typical of contiguity and neighbor weights z is
+// O(n). The number of steps is min(n, 2k+40).
+//
+// The Ritz values approach the extremal eigenvalues from the interior of the
+// spectrum, and those of largest magnitude converge first; the error of a
+// Ritz value is bounded by its residual, |β_m s_m|, where β_m is the final
+// Lanczos off-diagonal element and s_m the last component of the Ritz
+// vector. If the basis spans an invariant subspace of the locality before
+// the final step, the iteration is restarted with a random vector orthogonal
+// to the basis, so k values are always returned. A single Lanczos sequence
+// cannot resolve repeated eigenvalues, so without restarts an eigenvalue with
+// multiplicity greater than one is returned only once and the next distinct
+// eigenvalues take the place of its other copies.
+//
+// ApproxWeightsEigenvalues will panic if the locality is not square or if k
+// is not in [1, n].
+func ApproxWeightsEigenvalues(locality mat.Matrix, k int) []float64 {
+	n, c := locality.Dims()
+	if n != c {
+		panic("spatial: locality not square")
+	}
+	if k < 1 || n < k {
+		panic("spatial: invalid eigenvalue count")
+	}
+	steps := 2*k + 40
+	if steps > n {
+		steps = n
+	}
+
+	// Use a fixed pseudo-random starting vector so results
+	// are reproducible.
+	rnd := rand.New(rand.NewSource(1))
+	basis := make([][]float64, 0, steps)
+	q := make([]float64, n)
+	for i := range q {
+		q[i] = rnd.NormFloat64()
+	}
+	floats.Scale(1/floats.Norm(q, 2), q)
+
+	nz := nonZeros(locality)
+	alpha := make([]float64, 0, steps)
+	beta := make([]float64, 0, steps)
+	w := make([]float64, n)
+	for j := 0; j < steps; j++ {
+		basis = append(basis, q)
+		nz.symMulVec(w, q)
+		a := floats.Dot(w, q)
+		alpha = append(alpha, a)
+
+		// Full reorthogonalization against all basis vectors.
+		for pass := 0; pass < 2; pass++ {
+			for _, b := range basis {
+				floats.AddScaled(w, -floats.Dot(w, b), b)
+			}
+		}
+		if j == steps-1 {
+			break
+		}
+		norm := floats.Norm(w, 2)
+		if norm <= 1e-12*math.Max(1, math.Abs(a)) {
+			// The basis spans an invariant subspace, so restart
+			// with a random vector orthogonal to the basis.
+			for i := range w {
+				w[i] = rnd.NormFloat64()
+			}
+			for pass := 0; pass < 2; pass++ {
+				for _, b := range basis {
+					floats.AddScaled(w, -floats.Dot(w, b), b)
+				}
+			}
+			norm = floats.Norm(w, 2)
+			beta = append(beta, 0)
+		} else {
+			beta = append(beta, norm)
+		}
+		q = make([]float64, n)
+		copy(q, w)
+		floats.Scale(1/norm, q)
+	}
+
+	m := len(alpha)
+	t := mat.NewSymDense(m, nil)
+	for i, a := range alpha {
+		t.SetSym(i, i, a)
+		if i < len(beta) && i+1 < m {
+			t.SetSym(i, i+1, beta[i])
+		}
+	}
+	var e mat.EigenSym
+	if !e.Factorize(t, false) {
+		panic("spatial: eigendecomposition failed")
+	}
+	ritz := e.Values(nil)
+	if k > len(ritz) {
+		k = len(ritz)
+	}
+	sort.Sort(byMagnitude(ritz))
+	top := ritz[len(ritz)-k:]
+	sort.Sort(sort.Reverse(sort.Float64Slice(top)))
+	return top
+}
+
+// nonZero is a non-zero element of a locality.
+type nonZero struct {
+	i, j int
+	w    float64
+}
+
+// nonZeros returns the non-zero elements of the locality w.
+func nonZeros(w mat.Matrix) sparseLocality {
+	r, c := w.Dims()
+	var nz sparseLocality
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			if wij := w.At(i, j); wij != 0 {
+				nz = append(nz, nonZero{i: i, j: j, w: wij})
+			}
+		}
+	}
+	return nz
+}
+
+// sparseLocality holds the non-zero elements of a locality W.
+type sparseLocality []nonZero
+
+// symMulVec sets dst to (W + Wᵀ)/2 v.
+func (nz sparseLocality) symMulVec(dst, v []float64) {
+	for i := range dst {
+		dst[i] = 0
+	}
+	for _, e := range nz {
+		dst[e.i] += e.w * v[e.j] / 2
+		dst[e.j] += e.w * v[e.i] / 2
+	}
+}
+
+// byMagnitude sorts values by increasing absolute value.
+type byMagnitude []float64
+
+func (v byMagnitude) Len() int           { return len(v) }
+func (v byMagnitude) Less(i, j int) bool { return math.Abs(v[i]) < math.Abs(v[j]) }
+func (v byMagnitude) Swap(i, j int)      { v[i], v[j] = v[j], v[i] }
@@ -0,0 +1,64 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestApproxWeightsEigenvalues(t *testing.T) {
+	// The eigenvalues of the rook contiguity of a 9×14 grid
+	// are simple, 2cos(πi/10) + 2cos(πj/15). The grid is bipartite
+	// so the eigenvalues come in ±λ pairs, and the values of k are
+	// even so that the k largest in magnitude are well defined.
+	locality := gridLocality(9, 14)
+	exact := WeightsEigenvalues(locality)
+
+	for _, k := range []int{2, 4, 10} {
+		got := ApproxWeightsEigenvalues(locality, k)
+		want := make([]float64, len(exact))
+		copy(want, exact)
+		sort.Sort(byMagnitude(want))
+		want = want[len(want)-k:]
+		sort.Sort(sort.Reverse(sort.Float64Slice(want)))
+		if !floats.EqualApprox(got, want, 1e-8) {
+			t.Errorf("unexpected approximate eigenvalues for k=%d:\ngot: %v\nwant:%v", k, got, want)
+		}
+		if math.Abs(got[0]-exact[0]) > 1e-8 {
+			t.Errorf("unexpected largest eigenvalue for k=%d: got:%v want:%v", k, got[0], exact[0])
+		}
+	}
+}
+
+func TestApproxWeightsEigenvaluesRepeated(t *testing.T) {
+	// The complete graph on n nodes has eigenvalues n-1 and -1
+	// with multiplicity n-1. The Lanczos iteration breaks down
+	// after two steps and must be restarted to return k values.
+	const n = 10
+	locality := mat.NewDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i != j {
+				locality.Set(i, j, 1)
+			}
+		}
+	}
+	for _, k := range []int{1, 3, n} {
+		got := ApproxWeightsEigenvalues(locality, k)
+		want := make([]float64, k)
+		want[0] = n - 1
+		for i := 1; i < k; i++ {
+			want[i] = -1
+		}
+		if !floats.EqualApprox(got, want, 1e-10) {
+			t.Errorf("unexpected approximate eigenvalues for k=%d:\ngot: %v\nwant:%v", k, got, want)
+		}
+	}
+}
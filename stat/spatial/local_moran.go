@@ -0,0 +1,146 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"math"
+	"math/rand"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+// LocalMoran holds the data and locality for computing the local Moran's I
+// statistics, the local indicators of spatial association of Anselin (1995).
+type LocalMoran struct {
+	z []float64
+
+	// m2 is the second moment of the data.
+	m2 float64
+
+	// neighbors and weights hold the non-zero
+	// off-diagonal elements of each row of the
+	// locality.
+	neighbors [][]int
+	weights   [][]float64
+}
+
+// NewLocalMoran returns a LocalMoran for data over the given locality. The
+// diagonal of the locality is ignored. The non-zero elements of the locality
+// are retained so that the statistics for each unit are computed in time
+// proportional to the number of its neighbors. The locality must be square
+// with dimensions matching the length of data, otherwise NewLocalMoran will
+// panic.
+func NewLocalMoran(data []float64, locality mat.Matrix) *LocalMoran {
+	checkLocality(len(data), locality)
+	n := float64(len(data))
+	mean := floats.Sum(data) / n
+	l := &LocalMoran{
+		z:         make([]float64, len(data)),
+		neighbors: make([][]int, len(data)),
+		weights:   make([][]float64, len(data)),
+	}
+	for i, v := range data {
+		d := v - mean
+		l.z[i] = d
+		l.m2 += d * d
+	}
+	l.m2 /= n
+
+	for i := range data {
+		for j := range data {
+			if w := locality.At(i, j); j != i && w != 0 {
+				l.neighbors[i] = append(l.neighbors[i], j)
+				l.weights[i] = append(l.weights[i], w)
+			}
+		}
+	}
+	return l
+}
+
+// Ii returns the local Moran's I statistic for unit i,
+//  I_i = z_i / m_2 \sum_{j≠i} w_ij z_j,
+// where z holds the deviations of the data from their mean and m_2 is their
+// second moment.
+func (l *LocalMoran) Ii(i int) float64 {
+	var lag float64
+	for k, j := range l.neighbors[i] {
+		lag += l.weights[i][k] * l.z[j]
+	}
+	return l.z[i] / l.m2 * lag
+}
+
+// Z returns the z-score of the local Moran's I statistic for unit i under
+// the conditional randomization assumption, where the value at unit i is
+// held fixed and the remaining values are randomly permuted over the other
+// units. The mean and variance of the spatial lag of unit i are those of a
+// weighted sum of a sample without replacement of size equal to the number
+// of neighbors from the N = n-1 other values,
+//  E[L] = w_i μ,  Var[L] = σ² (N w_i(2) - w_i²) / (N-1),
+// where μ and σ² are the mean and population variance of the other values,
+// w_i is the sum of the weights of unit i and w_i(2) the sum of their
+// squares.
+func (l *LocalMoran) Z(i int) float64 {
+	n := float64(len(l.z))
+	var wi, wi2 float64
+	for _, w := range l.weights[i] {
+		wi += w
+		wi2 += w * w
+	}
+	zi := l.z[i]
+	others := n - 1
+	mu := -zi / others
+	sigma2 := (n*l.m2-zi*zi)/others - mu*mu
+	scale := zi / l.m2
+	e := scale * wi * mu
+	v := scale * scale * sigma2 * (others*wi2 - wi*wi) / (others - 1)
+	return (l.Ii(i) - e) / math.Sqrt(v)
+}
+
+// PermutationP returns the conditional permutation pseudo p-value of the local
+// Moran's I statistic for unit i using perms permutations. The value at unit
+// i is held fixed and the values at its neighbors are randomly drawn without
+// replacement from the values at the other n-1 units, as computed by
+// ConditionalPermute. If src is nil, the global rand source is used.
+// PermutationP will panic if perms is not positive.
+func (l *LocalMoran) PermutationP(i, perms int, src rand.Source) float64 {
+	weights := l.weights[i]
+	return ConditionalPermute(i, l.z, l.neighbors[i], func(zi float64, lag []float64) float64 {
+		return zi / l.m2 * floats.Dot(weights, lag)
+	}, perms, src)
+}
+
+// Cluster returns the cluster type of unit i. The unit is high or low
+// according to the sign of its deviation from the mean and its neighbors
+// are high or low according to the sign of its spatial lag. If the
+// permutation p-value returned by PermutationP using perms permutations and
+// src is not less than alpha, or the deviation or spatial lag is zero,
+// Cluster returns ClusterNone. Cluster will panic if alpha is not in (0, 1)
+// or perms is not positive.
+func (l *LocalMoran) Cluster(i int, alpha float64, perms int, src rand.Source) LISACluster {
+	if !(0 < alpha && alpha < 1) {
+		panic("spatial: alpha out of range")
+	}
+	if l.PermutationP(i, perms, src) >= alpha {
+		return ClusterNone
+	}
+	var lag float64
+	for k, j := range l.neighbors[i] {
+		lag += l.weights[i][k] * l.z[j]
+	}
+	zi := l.z[i]
+	switch {
+	case zi > 0 && lag > 0:
+		return ClusterHH
+	case zi < 0 && lag < 0:
+		return ClusterLL
+	case zi > 0 && lag < 0:
+		return ClusterHL
+	case zi < 0 && lag > 0:
+		return ClusterLH
+	default:
+		return ClusterNone
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestLocalMoran(t *testing.T) {
+	locality := gridLocality(4, 4)
+	l := NewLocalMoran(clusteredGrid, locality)
+
+	// With a zero diagonal the local statistics sum to
+	// S0 times the global statistic.
+	var sum float64
+	for i := range clusteredGrid {
+		sum += l.Ii(i)
+	}
+	if want := mat.Sum(locality) * moransI(clusteredGrid, locality); !floats.EqualWithinAbsOrRel(sum, want, 1e-12, 1e-12) {
+		t.Errorf("local statistics do not sum to scaled global statistic: got:%v want:%v", sum, want)
+	}
+	if z := l.Z(0); z < 1.96 {
+		t.Errorf("high-valued corner not significant: z=%v", z)
+	}
+}
+
+func TestLocalMoranConditionalRandomization(t *testing.T) {
+	// Enumerate all conditional permutations, holding the value at
+	// the focal unit fixed, to obtain the exact conditional mean
+	// and variance of the local statistic.
+	locality := mat.NewDense(6, 6, []float64{
+		0, 1, 0, 1, 0, 0,
+		1, 0, 1, 0, 2, 0,
+		0, 1, 0, 0, 0, 1,
+		1, 0, 0, 0, 1, 0,
+		0, 0.5, 0, 1, 0, 1,
+		0, 0, 1, 0, 1, 0,
+	})
+	data := []float64{3, 0.5, 1, 7, 2, 2.5}
+	l := NewLocalMoran(data, locality)
+	for i := range data {
+		var others []float64
+		for j, v := range data {
+			if j != i {
+				others = append(others, v)
+			}
+		}
+		var sum, sumSq, count float64
+		perm := make([]float64, len(data))
+		permute(len(others), func(p []int) {
+			perm[i] = data[i]
+			k := 0
+			for j := range perm {
+				if j == i {
+					continue
+				}
+				perm[j] = others[p[k]]
+				k++
+			}
+			s := NewLocalMoran(perm, locality).Ii(i)
+			sum += s
+			sumSq += s * s
+			count++
+		})
+		mean := sum / count
+		want := (l.Ii(i) - mean) / math.Sqrt(sumSq/count-mean*mean)
+		if got := l.Z(i); !floats.EqualWithinAbsOrRel(got, want, 1e-10, 1e-10) {
+			t.Errorf("unexpected z-score for unit %d: got:%v want:%v", i, got, want)
+		}
+	}
+}
+
+func TestLocalMoranPermutationP(t *testing.T) {
+	l := NewLocalMoran(clusteredGrid, gridLocality(4, 4))
+	if p := l.PermutationP(0, 999, rand.NewSource(1)); p >= 0.05 {
+		t.Errorf("high-valued corner not significant: p=%v", p)
+	}
+
+	// Compare against the exact conditional permutation
+	// distribution on a grid small enough to enumerate.
+	data := []float64{
+		0.5, 1.1, -0.3,
+		0.8, -0.4, 0.2,
+		-0.2, 0.3, -1.1,
+	}
+	locality := gridLocality(3, 3)
+	l = NewLocalMoran(data, locality)
+	for _, i := range []int{0, 4, 5} {
+		obs := l.Ii(i)
+		var others []float64
+		for j, v := range data {
+			if j != i {
+				others = append(others, v)
+			}
+		}
+		var larger, count int
+		perm := make([]float64, len(data))
+		permute(len(others), func(p []int) {
+			perm[i] = data[i]
+			k := 0
+			for j := range perm {
+				if j == i {
+					continue
+				}
+				perm[j] = others[p[k]]
+				k++
+			}
+			// Permutations of the non-neighbors give exact
+			// ties, so allow for rounding error.
+			if NewLocalMoran(perm, locality).Ii(i) >= obs-1e-12 {
+				larger++
+			}
+			count++
+		})
+		if count-larger < larger {
+			larger = count - larger
+		}
+		want := float64(larger) / float64(count)
+		if got := l.PermutationP(i, 20000, rand.NewSource(1)); math.Abs(got-want) > 0.01 {
+			t.Errorf("unexpected p-value for unit %d: got:%v want:%v", i, got, want)
+		}
+	}
+}
+
+func TestLocalMoranCluster(t *testing.T) {
+	// A high outlier at unit 10 in an otherwise
+	// clustered grid.
+	data := []float64{
+		9, 8, 2, 1,
+		8, 7, 2, 1,
+		3, 2, 9, 0,
+		2, 1, 0, 1,
+	}
+	l := NewLocalMoran(data, gridLocality(4, 4))
+
+	// With a permissive significance level only
+	// the signs determine the cluster type.
+	for _, test := range []struct {
+		unit int
+		want LISACluster
+	}{
+		{unit: 0, want: ClusterHH},
+		{unit: 15, want: ClusterLL},
+		{unit: 10, want: ClusterHL},
+		{unit: 14, want: ClusterLH},
+	} {
+		if got := l.Cluster(test.unit, 0.9, 99, rand.NewSource(1)); got != test.want {
+			t.Errorf("unexpected cluster type for unit %d: got:%v want:%v", test.unit, got, test.want)
+		}
+	}
+
+	if got := l.Cluster(0, 0.05, 999, rand.NewSource(1)); got != ClusterHH {
+		t.Errorf("unexpected cluster type for significant unit: got:%v want:%v", got, ClusterHH)
+	}
+	if p := l.PermutationP(3, 999, rand.NewSource(1)); p < 0.05 {
+		t.Fatalf("unexpected significance for unit 3: p=%v", p)
+	}
+	if got := l.Cluster(3, 0.05, 999, rand.NewSource(1)); got != ClusterNone {
+		t.Errorf("unexpected cluster type for non-significant unit: got:%v want:%v", got, ClusterNone)
+	}
+}
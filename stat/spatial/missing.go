@@ -0,0 +1,42 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// DropMissing returns the non-NaN values of data and the locality restricted
+// to the units holding them, along with the indices of those units in data.
+// The rows and columns of the locality for units with NaN values are dropped,
+// so statistics computed from the returned data and locality exclude the
+// missing units from the mean and variance and from all neighbor sums. The
+// statistic for a non-missing unit then reflects only its non-missing
+// neighbors. The returned locality is a copy and the indices are in
+// ascending order. If all values are NaN, the returned locality is empty.
+//
+// The locality must be square with dimensions matching the length of data,
+// otherwise DropMissing will panic.
+func DropMissing(data []float64, locality mat.Matrix) (kept []float64, sub *mat.Dense, idx []int) {
+	checkLocality(len(data), locality)
+	for i, v := range data {
+		if !math.IsNaN(v) {
+			kept = append(kept, v)
+			idx = append(idx, i)
+		}
+	}
+	if len(idx) == 0 {
+		return kept, &mat.Dense{}, idx
+	}
+	sub = mat.NewDense(len(idx), len(idx), nil)
+	for r, i := range idx {
+		for c, j := range idx {
+			sub.Set(r, c, locality.At(i, j))
+		}
+	}
+	return kept, sub, idx
+}
@@ -0,0 +1,64 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestDropMissing(t *testing.T) {
+	nan := math.NaN()
+	data := []float64{
+		9, nan, 2,
+		8, 7, nan,
+		3, 2, 1,
+	}
+	locality := gridLocality(3, 3)
+
+	kept, sub, idx := DropMissing(data, locality)
+	if want := []int{0, 2, 3, 4, 6, 7, 8}; !reflect.DeepEqual(idx, want) {
+		t.Fatalf("unexpected indices: got:%v want:%v", idx, want)
+	}
+
+	// Build the equivalent pre-filtered data and locality by hand.
+	// The missing units 1 and 5 are dropped, leaving unit 2 isolated.
+	wantData := []float64{9, 2, 8, 7, 3, 2, 1}
+	wantLocality := mat.NewDense(7, 7, []float64{
+		0, 0, 1, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0,
+		1, 0, 0, 1, 1, 0, 0,
+		0, 0, 1, 0, 0, 1, 0,
+		0, 0, 1, 0, 0, 1, 0,
+		0, 0, 0, 1, 1, 0, 1,
+		0, 0, 0, 0, 0, 1, 0,
+	})
+	if !reflect.DeepEqual(kept, wantData) {
+		t.Errorf("unexpected data: got:%v want:%v", kept, wantData)
+	}
+	if !mat.Equal(sub, wantLocality) {
+		t.Errorf("unexpected locality:\ngot: %v\nwant:%v", mat.Formatted(sub), mat.Formatted(wantLocality))
+	}
+
+	gotI, _, _ := MoransIExact(kept, sub)
+	wantI, _, _ := MoransIExact(wantData, wantLocality)
+	if gotI != wantI || math.IsNaN(gotI) {
+		t.Errorf("unexpected Moran's I: got:%v want:%v", gotI, wantI)
+	}
+	_, gotGstar := GandGstarAll(kept, sub)
+	_, wantGstar := GandGstarAll(wantData, wantLocality)
+	if !floats.Same(gotGstar, wantGstar) {
+		t.Errorf("unexpected G*i: got:%v want:%v", gotGstar, wantGstar)
+	}
+
+	kept, sub, idx = DropMissing([]float64{nan, nan}, mat.NewDense(2, 2, nil))
+	if r, c := sub.Dims(); len(kept) != 0 || len(idx) != 0 || r != 0 || c != 0 {
+		t.Errorf("unexpected result for all missing data: data:%v dims:%d×%d idx:%v", kept, r, c, idx)
+	}
+}
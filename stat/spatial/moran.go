@@ -0,0 +1,470 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"math"
+	"math/rand"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// MoransIExactMoments returns the exact mean and variance of Moran's I
+// under the normality assumption for the given locality, following
+// Tiefelsdorf and Boots (1995). The moments are computed from the
+// eigenvalues of M W* M where W* = (W + Wᵀ)/2 is the symmetrized locality
+// and M = I - 11ᵀ/n is the projection removing the mean. The locality must
+// be square, otherwise MoransIExactMoments will panic.
+//
+// For a model containing only a mean, the exact moments agree with the
+// normality assumption moments of Cliff and Ord. They differ from the
+// randomization assumption moments when the data are far from normal,
+// most noticeably for small n with a strongly skewed or heavy-tailed
+// distribution, since the randomization variance depends on the sample
+// kurtosis.
+func MoransIExactMoments(locality mat.Matrix) (eMean, eVar float64) {
+	r, c := locality.Dims()
+	if r != c {
+		panic("spatial: locality not square")
+	}
+	return exactMoments(projectedEigenvalues(locality), r, mat.Sum(locality))
+}
+
+// MoransIExact returns Moran's I for data over the given locality with its
+// exact variance under the normality assumption and the corresponding
+// z-score. Self weights on the diagonal of the locality are included in the
+// statistic and its moments; ZeroDiagonal gives a locality without them.
+// Moran's I is undefined for data with zero variance, so if data is constant
+// i and z are NaN. The locality must be square with dimensions matching the length of data,
+// otherwise MoransIExact will panic.
+func MoransIExact(data []float64, locality mat.Matrix) (i, v, z float64) {
+	checkLocality(len(data), locality)
+	i = moransI(data, locality)
+	e, v := MoransIExactMoments(locality)
+	return i, v, (i - e) / math.Sqrt(v)
+}
+
+// MoransINormal returns Moran's I for data over the given locality with its
+// variance under the normality assumption and the corresponding z-score. The
+// moments are computed in closed form from the weight sums S_0, S_1 and S_2
+// and the trace of the locality,
+//  E[I] = (n tr(W) - S_0) / ((n-1) S_0),
+//  Var[I] = 2 n^2 ((n-1) T_2 - T_1^2) / ((n-1)^2 (n+1) S_0^2),
+// where T_1 = tr(W) - S_0/n and T_2 = S_1/2 - S_2/(2n) + S_0^2/n^2 are the
+// traces of M W* M and its square, with W* and M as for MoransIExactMoments.
+// They agree with the exact moments used by MoransIExact, including for a
+// locality with a non-zero diagonal, but are computed without an
+// eigendecomposition of the locality. For a zero diagonal they reduce to the
+// moments of Cliff and Ord,
+//  E[I] = -1/(n-1),
+//  Var[I] = (n^2 S_1 - n S_2 + 3 S_0^2) / ((n^2-1) S_0^2) - E[I]^2.
+//
+// The normality assumption treats the data as independent draws from a
+// normal distribution, while the randomization assumption treats the
+// observed values as fixed and considers their permutations over the units.
+// The randomization variance depends on the kurtosis of the data, and the
+// two are close for data with kurtosis near that of a normal sample. The
+// normality variance is appropriate when the data, or the residuals of a
+// model, are plausibly normal; for strongly skewed or heavy-tailed data a
+// permutation test such as MoranPermutationP is more reliable.
+//
+// As for MoransIExact, i and z are NaN if data is constant. The locality
+// must be square with dimensions matching the length of data, otherwise
+// MoransINormal will panic.
+func MoransINormal(data []float64, locality mat.Matrix) (i, v, z float64) {
+	checkLocality(len(data), locality)
+	i = moransI(data, locality)
+
+	r := len(data)
+	n := float64(r)
+	var tr, s0, s1, s2 float64
+	for p := 0; p < r; p++ {
+		tr += locality.At(p, p)
+		var row, col float64
+		for q := 0; q < r; q++ {
+			w := locality.At(p, q)
+			s0 += w
+			s := w + locality.At(q, p)
+			s1 += s * s
+			row += w
+			col += locality.At(q, p)
+		}
+		s2 += (row + col) * (row + col)
+	}
+	s1 /= 2
+	t1 := tr - s0/n
+	t2 := s1/2 - s2/(2*n) + s0*s0/(n*n)
+	e := n * t1 / ((n - 1) * s0)
+	v = 2 * n * n * ((n-1)*t2 - t1*t1) / ((n - 1) * (n - 1) * (n + 1) * s0 * s0)
+	return i, v, (i - e) / math.Sqrt(v)
+}
+
+// MoransIAuto returns Moran's I for data over a row-standardized copy of
+// the given locality with its exact variance under the normality assumption
+// and the corresponding z-score, as returned by MoransIExact. Each row of the
+// copy is divided by its sum so that the spatial lag of a unit is the
+// weighted mean of its neighbors, making results comparable across weighting
+// schemes. Rows summing to zero are left unchanged. The locality is not
+// modified.
+//
+// Row standardization generally makes the weights asymmetric and changes
+// their sums of squares, so the variance and z-score differ from those
+// obtained with the raw weights even when Moran's I itself is similar. The
+// locality must be square with dimensions matching the length of data,
+// otherwise MoransIAuto will panic.
+func MoransIAuto(data []float64, locality mat.Matrix) (i, v, z float64) {
+	checkLocality(len(data), locality)
+	return MoransIExact(data, rowStandardized(locality))
+}
+
+// EmpiricalBayesMoran returns Moran's I for the rates events[i]/population[i]
+// over the given locality after the empirical Bayes standardization of
+// Assunção and Reis (1999), with the variance and z-score of MoransINormal
+// for the standardized rates. Raw rates for units with small populations
+// have larger variance than those for units with large populations, which
+// biases Moran's I computed from the rates directly. The standardized rate
+// of unit i is
+//  z_i = (r_i - b) / sqrt(v_i),  v_i = a + b/p_i,
+// where r_i is the rate, p_i the population, b the overall rate and
+//  a = s^2 - b/p̄,  s^2 = \sum_i p_i (r_i - b)^2 / \sum_i p_i,
+// with p̄ the mean population. If v_i is negative, b/p_i is used in its
+// place.
+//
+// EmpiricalBayesMoran will panic if the lengths of events and population
+// differ, if any event count is negative or population is not positive, or
+// if the locality is not square with dimensions matching the length of the
+// data.
+func EmpiricalBayesMoran(events, population []float64, locality mat.Matrix) (i, v, z float64) {
+	if len(events) != len(population) {
+		panic("spatial: data length mismatch")
+	}
+	checkLocality(len(events), locality)
+	return MoransINormal(ebStandardizedRates(events, population), locality)
+}
+
+// ebStandardizedRates returns the empirical Bayes standardized rates of
+// Assunção and Reis (1999) for the events and population.
+func ebStandardizedRates(events, population []float64) []float64 {
+	var sumE, sumP float64
+	for k, e := range events {
+		if e < 0 {
+			panic("spatial: negative event count")
+		}
+		if population[k] <= 0 {
+			panic("spatial: non-positive population")
+		}
+		sumE += e
+		sumP += population[k]
+	}
+	b := sumE / sumP
+	meanP := sumP / float64(len(population))
+
+	rates := make([]float64, len(events))
+	var s2 float64
+	for k, e := range events {
+		rates[k] = e / population[k]
+		d := rates[k] - b
+		s2 += population[k] * d * d
+	}
+	s2 /= sumP
+	a := s2 - b/meanP
+
+	for k, p := range population {
+		v := a + b/p
+		if v < 0 {
+			v = b / p
+		}
+		rates[k] = (rates[k] - b) / math.Sqrt(v)
+	}
+	return rates
+}
+
+// MoransIExactPValue returns the upper tail p-value, P(I >= i), of the
+// observed Moran's I for data over the given locality under the normality
+// assumption. The distribution of Moran's I is that of a ratio of quadratic
+// forms in normal variables determined by the eigenvalues of the projected
+// symmetrized locality, and MoransIExactPValue evaluates its tail using the
+// saddlepoint approximation of Lugannani and Rice as described by
+// Tiefelsdorf (2002). The approximation is considerably more accurate than
+// the normal approximation for small samples. MoransIExactPValue returns NaN
+// if data is constant. The locality must be square with dimensions matching
+// the length of data, otherwise MoransIExactPValue will panic.
+func MoransIExactPValue(data []float64, locality mat.Matrix) float64 {
+	checkLocality(len(data), locality)
+	i := moransI(data, locality)
+	if math.IsNaN(i) {
+		return math.NaN()
+	}
+	r := i * mat.Sum(locality) / float64(len(data))
+
+	// P(I >= i) = P(Σ_k (λ_k - r) χ²_1 >= 0).
+	lambda := projectedEigenvalues(locality)
+	nu := make([]float64, len(lambda))
+	for k, l := range lambda {
+		nu[k] = l - r
+	}
+	return 1 - quadFormCDFZero(nu)
+}
+
+// MoransIContributions returns the contribution of each unit to Moran's I for
+// data over the given locality,
+//  c_i = n / S_0 z_i \sum_j w_ij z_j,
+// where z holds the deviations of the data from their mean and S_0 is the sum
+// of the weights. The contributions sum to the numerator of Moran's I, so
+//  I = \sum_i c_i / \sum_i z_i^2,
+// and units with the largest contributions are those that drive the global
+// statistic. The locality must be square with dimensions matching the length
+// of data, otherwise MoransIContributions will panic.
+func MoransIContributions(data []float64, locality mat.Matrix) []float64 {
+	checkLocality(len(data), locality)
+	mean := floats.Sum(data) / float64(len(data))
+	z := make([]float64, len(data))
+	for i, v := range data {
+		z[i] = v - mean
+	}
+
+	c := make([]float64, len(data))
+	var s0 float64
+	for i, zi := range z {
+		var lag float64
+		for j, zj := range z {
+			w := locality.At(i, j)
+			lag += w * zj
+			s0 += w
+		}
+		c[i] = zi * lag
+	}
+	floats.Scale(float64(len(data))/s0, c)
+	return c
+}
+
+// MoranPermutationP returns the two-sided permutation pseudo p-value of
+// Moran's I for data over the given locality using perms random permutations
+// of the data over the units. If a of the permutations give a statistic at
+// least as large as the observed statistic and b give a statistic at most as
+// large, the returned value is
+//  min(1, 2 (min(a, b) + 1) / (perms + 1)).
+// The permutation test makes no assumption about the distribution of the
+// data. If src is nil, the global rand source is used. The permutation
+// reference distribution can be obtained with the same src using
+// MoranPermutations. If data is constant, Moran's I is undefined and
+// MoranPermutationP returns NaN.
+//
+// MoranPermutationP will panic if perms is not positive or if the locality is
+// not square with dimensions matching the length of data.
+func MoranPermutationP(data []float64, locality mat.Matrix, perms int, src rand.Source) float64 {
+	if perms < 1 {
+		panic("spatial: invalid permutation count")
+	}
+	checkLocality(len(data), locality)
+	obs := moransI(data, locality)
+	if math.IsNaN(obs) {
+		return math.NaN()
+	}
+	var above, below int
+	for _, v := range MoranPermutations(make([]float64, perms), data, locality, src) {
+		if v >= obs {
+			above++
+		}
+		if v <= obs {
+			below++
+		}
+	}
+	if below < above {
+		above = below
+	}
+	return math.Min(1, 2*float64(above+1)/float64(perms+1))
+}
+
+// MoranPermutations fills dst with the values of Moran's I for random
+// permutations of data over the given locality and returns it, giving the
+// reference distribution used by MoranPermutationP. If src is nil, the global
+// rand source is used. The locality must be square with dimensions matching
+// the length of data, otherwise MoranPermutations will panic.
+func MoranPermutations(dst, data []float64, locality mat.Matrix, src rand.Source) []float64 {
+	checkLocality(len(data), locality)
+	perm := rand.Perm
+	if src != nil {
+		perm = rand.New(src).Perm
+	}
+	shuffled := make([]float64, len(data))
+	for r := range dst {
+		for k, j := range perm(len(data)) {
+			shuffled[k] = data[j]
+		}
+		dst[r] = moransI(shuffled, locality)
+	}
+	return dst
+}
+
+// quadFormCDFZero returns the saddlepoint approximation of P(Q <= 0) where
+// Q = Σ_k nu_k χ²_1 for independent chi-squared variables.
+func quadFormCDFZero(nu []float64) float64 {
+	min, max := floats.Min(nu), floats.Max(nu)
+	switch {
+	case min >= 0:
+		return 0
+	case max <= 0:
+		return 1
+	}
+
+	// The cumulant generating function K(s) = -½ Σ log(1 - 2 s nu_k)
+	// is defined for s in (1/(2 min), 1/(2 max)). The saddlepoint
+	// solves K'(s) = 0, and K' is increasing on the interval.
+	dK := func(s float64) float64 {
+		var d float64
+		for _, v := range nu {
+			d += v / (1 - 2*s*v)
+		}
+		return d
+	}
+	lo, hi := 1/(2*min), 1/(2*max)
+	s := 0.0
+	for iter := 0; iter < 200; iter++ {
+		if dK(s) < 0 {
+			lo = s
+		} else {
+			hi = s
+		}
+		next := (lo + hi) / 2
+		if next == s {
+			break
+		}
+		s = next
+	}
+
+	var k, d2K float64
+	for _, v := range nu {
+		t := 1 - 2*s*v
+		k -= math.Log(t) / 2
+		d2K += 2 * v * v / (t * t)
+	}
+	if s == 0 {
+		// The saddlepoint is at the origin, so the approximation
+		// reduces to its limit of one half.
+		return 0.5
+	}
+	w := math.Copysign(math.Sqrt(-2*k), s)
+	u := s * math.Sqrt(d2K)
+	return distuv.UnitNormal.CDF(w) + distuv.UnitNormal.Prob(w)*(1/w-1/u)
+}
+
+// exactMoments returns the exact moments of Moran's I for n observations
+// given the non-trivial eigenvalues of the projected symmetrized weights and
+// the sum of all weights.
+func exactMoments(lambda []float64, n int, s0 float64) (eMean, eVar float64) {
+	df := float64(len(lambda))
+	var sum, sumSq float64
+	for _, l := range lambda {
+		sum += l
+		sumSq += l * l
+	}
+	scale := float64(n) / s0
+	eMean = scale * sum / df
+	eVar = scale * scale * 2 * (df*sumSq - sum*sum) / (df * df * (df + 2))
+	return eMean, eVar
+}
+
+// projectedEigenvalues returns the n-1 eigenvalues of M W* M in ascending
+// order where W* is the symmetrized locality and M is the mean-removing
+// projection. The zero eigenvalue belonging to the constant eigenvector
+// removed by M is omitted.
+func projectedEigenvalues(locality mat.Matrix) []float64 {
+	n, _ := locality.Dims()
+	b := symmetrized(locality)
+
+	// Apply M on both sides by removing row and column means.
+	rowMeans := make([]float64, n)
+	var mean float64
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			rowMeans[i] += b.At(i, j)
+		}
+		mean += rowMeans[i]
+		rowMeans[i] /= float64(n)
+	}
+	mean /= float64(n * n)
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			b.SetSym(i, j, b.At(i, j)-rowMeans[i]-rowMeans[j]+mean)
+		}
+	}
+
+	var e mat.EigenSym
+	if !e.Factorize(b, true) {
+		panic("spatial: eigendecomposition failed")
+	}
+	values := e.Values(nil)
+	var vectors mat.Dense
+	vectors.EigenvectorsSym(&e)
+
+	// Find the eigenvector most closely aligned with the constant vector.
+	trivial := -1
+	var best float64
+	for j := 0; j < n; j++ {
+		var sum float64
+		for i := 0; i < n; i++ {
+			sum += vectors.At(i, j)
+		}
+		if math.Abs(sum) > best {
+			best = math.Abs(sum)
+			trivial = j
+		}
+	}
+	return append(values[:trivial], values[trivial+1:]...)
+}
+
+// moransI returns Moran's I for data over the given locality, or NaN if data
+// is constant. No checks are made on the dimensions of the locality.
+func moransI(data []float64, locality mat.Matrix) float64 {
+	if len(data) == 0 || floats.Min(data) == floats.Max(data) {
+		return math.NaN()
+	}
+	mean := floats.Sum(data) / float64(len(data))
+	z := make([]float64, len(data))
+	for i, v := range data {
+		z[i] = v - mean
+	}
+
+	var num, s0 float64
+	for i, zi := range z {
+		for j, zj := range z {
+			w := locality.At(i, j)
+			num += w * zi * zj
+			s0 += w
+		}
+	}
+	return float64(len(data)) / s0 * num / floats.Dot(z, z)
+}
+
+// rowStandardized returns a copy of w with each row divided by its sum.
+// Rows summing to zero are copied unchanged.
+func rowStandardized(w mat.Matrix) *mat.Dense {
+	var s mat.Dense
+	s.Clone(w)
+	r, _ := s.Dims()
+	for i := 0; i < r; i++ {
+		row := s.RawRowView(i)
+		sum := floats.Sum(row)
+		if sum == 0 {
+			continue
+		}
+		floats.Scale(1/sum, row)
+	}
+	return &s
+}
+
+// checkLocality panics if locality is not an n×n matrix.
+func checkLocality(n int, locality mat.Matrix) {
+	r, c := locality.Dims()
+	if r != c {
+		panic("spatial: locality not square")
+	}
+	if n != r {
+		panic("spatial: data length mismatch")
+	}
+}
@@ -0,0 +1,402 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+// gridLocality returns binary rook contiguity weights for an r×c grid with
+// cells numbered in row-major order.
+func gridLocality(r, c int) *mat.Dense {
+	n := r * c
+	w := mat.NewDense(n, n, nil)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			u := i*c + j
+			if i > 0 {
+				w.Set(u, u-c, 1)
+			}
+			if i < r-1 {
+				w.Set(u, u+c, 1)
+			}
+			if j > 0 {
+				w.Set(u, u-1, 1)
+			}
+			if j < c-1 {
+				w.Set(u, u+1, 1)
+			}
+		}
+	}
+	return w
+}
+
+// clusteredGrid is 4×4 grid data with high values clustered in one corner.
+var clusteredGrid = []float64{
+	9, 8, 2, 1,
+	8, 7, 2, 1,
+	3, 2, 1, 0,
+	2, 1, 0, 1,
+}
+
+// normalMoments returns the Cliff and Ord moments of Moran's I under the
+// normality assumption.
+func normalMoments(w mat.Matrix) (e, v float64) {
+	r, _ := w.Dims()
+	n := float64(r)
+	var s0, s1, s2 float64
+	for i := 0; i < r; i++ {
+		var row, col float64
+		for j := 0; j < r; j++ {
+			s0 += w.At(i, j)
+			s := w.At(i, j) + w.At(j, i)
+			s1 += s * s
+			row += w.At(i, j)
+			col += w.At(j, i)
+		}
+		s2 += (row + col) * (row + col)
+	}
+	s1 /= 2
+	e = -1 / (n - 1)
+	v = (n*n*s1-n*s2+3*s0*s0)/((n*n-1)*s0*s0) - e*e
+	return e, v
+}
+
+func TestMoransIExactMoments(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		locality mat.Matrix
+	}{
+		{name: "grid", locality: gridLocality(4, 4)},
+		{name: "chain", locality: gridLocality(1, 7)},
+		{name: "asymmetric", locality: mat.NewDense(4, 4, []float64{
+			0, 1, 0, 0,
+			0.5, 0, 0.5, 0,
+			0, 0.5, 0, 0.5,
+			0, 0, 2, 0,
+		})},
+	} {
+		gotE, gotV := MoransIExactMoments(test.locality)
+		wantE, wantV := normalMoments(test.locality)
+		if !floats.EqualWithinAbsOrRel(gotE, wantE, 1e-12, 1e-12) {
+			t.Errorf("unexpected mean for %s: got:%v want:%v", test.name, gotE, wantE)
+		}
+		if !floats.EqualWithinAbsOrRel(gotV, wantV, 1e-12, 1e-12) {
+			t.Errorf("unexpected variance for %s: got:%v want:%v", test.name, gotV, wantV)
+		}
+	}
+}
+
+func TestMoransIExact(t *testing.T) {
+	locality := gridLocality(4, 4)
+	i, v, z := MoransIExact(clusteredGrid, locality)
+	e, wantV := normalMoments(locality)
+	if wantZ := (i - e) / math.Sqrt(wantV); !floats.EqualWithinAbsOrRel(z, wantZ, 1e-12, 1e-12) {
+		t.Errorf("unexpected z-score: got:%v want:%v", z, wantZ)
+	}
+	if math.IsNaN(v) || v <= 0 {
+		t.Errorf("unexpected variance: %v", v)
+	}
+	if i <= 0 || z < 1.96 {
+		t.Errorf("clustered data not significantly autocorrelated: I=%v z=%v", i, z)
+	}
+}
+
+func TestMoransINormal(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		locality mat.Matrix
+	}{
+		{name: "grid", locality: gridLocality(4, 4)},
+		{name: "asymmetric", locality: rowStandardized(gridLocality(4, 4))},
+		{name: "diagonal", locality: func() mat.Matrix {
+			w := gridLocality(4, 4)
+			w.Set(0, 0, 2)
+			w.Set(5, 5, 1)
+			return w
+		}()},
+	} {
+		i, v, z := MoransINormal(clusteredGrid, test.locality)
+		wantI, wantV, wantZ := MoransIExact(clusteredGrid, test.locality)
+		if i != wantI {
+			t.Errorf("unexpected Moran's I for %s: got:%v want:%v", test.name, i, wantI)
+		}
+		if !floats.EqualWithinAbsOrRel(v, wantV, 1e-12, 1e-12) {
+			t.Errorf("unexpected variance for %s: got:%v want:%v", test.name, v, wantV)
+		}
+		if !floats.EqualWithinAbsOrRel(z, wantZ, 1e-10, 1e-10) {
+			t.Errorf("unexpected z-score for %s: got:%v want:%v", test.name, z, wantZ)
+		}
+	}
+}
+
+func TestMoransIZeroDiagonal(t *testing.T) {
+	grid := gridLocality(4, 4)
+	w := gridLocality(4, 4)
+	w.Set(0, 0, 2)
+	w.Set(5, 5, 1)
+	w.Set(10, 10, 0.5)
+
+	excluded := ZeroDiagonal(w)
+	if got := w.At(5, 5); got != 1 {
+		t.Errorf("locality modified: got:%v want:1", got)
+	}
+	if !mat.Equal(excluded, grid) {
+		t.Errorf("unexpected locality with zeroed diagonal:\ngot: %v\nwant:%v", mat.Formatted(excluded), mat.Formatted(grid))
+	}
+
+	// Zeroing the diagonal removes w_ii z_i^2 from the numerator
+	// and tr(W) from S_0.
+	raw, _, _ := MoransINormal(clusteredGrid, w)
+	got, _, _ := MoransINormal(clusteredGrid, excluded)
+	n := float64(len(clusteredGrid))
+	mean := floats.Sum(clusteredGrid) / n
+	var ss, self float64
+	for i, v := range clusteredGrid {
+		z := v - mean
+		ss += z * z
+		self += w.At(i, i) * z * z
+	}
+	s0 := mat.Sum(w)
+	tr := mat.Trace(w)
+	want := n / (s0 - tr) * (raw*s0/n*ss - self) / ss
+	if !floats.EqualWithinAbsOrRel(got, want, 1e-12, 1e-12) {
+		t.Errorf("unexpected Moran's I with zeroed diagonal: got:%v want:%v", got, want)
+	}
+	if got == raw {
+		t.Errorf("Moran's I unchanged by zeroing the diagonal: %v", got)
+	}
+	if e, _ := MoransIExactMoments(excluded); !floats.EqualWithinAbsOrRel(e, -1/(n-1), 1e-12, 1e-12) {
+		t.Errorf("unexpected expected value with zeroed diagonal: got:%v want:%v", e, -1/(n-1))
+	}
+}
+
+func TestMoransIConstant(t *testing.T) {
+	// Moran's I is undefined for constant data, even when
+	// rounding gives non-zero deviations from the mean.
+	locality := gridLocality(4, 4)
+	data := make([]float64, 16)
+	for i := range data {
+		data[i] = 0.1
+	}
+	if i, _, z := MoransIExact(data, locality); !math.IsNaN(i) || !math.IsNaN(z) {
+		t.Errorf("unexpected exact Moran's I for constant data: got:(%v, %v) want:(NaN, NaN)", i, z)
+	}
+	if i, _, z := MoransINormal(data, locality); !math.IsNaN(i) || !math.IsNaN(z) {
+		t.Errorf("unexpected normal Moran's I for constant data: got:(%v, %v) want:(NaN, NaN)", i, z)
+	}
+	if p := MoransIExactPValue(data, locality); !math.IsNaN(p) {
+		t.Errorf("unexpected exact p-value for constant data: got:%v want:NaN", p)
+	}
+	if p := MoranPermutationP(data, locality, 99, rand.NewSource(1)); !math.IsNaN(p) {
+		t.Errorf("unexpected permutation p-value for constant data: got:%v want:NaN", p)
+	}
+}
+
+func TestMoransIAuto(t *testing.T) {
+	locality := gridLocality(4, 4)
+	n, _ := locality.Dims()
+	standardized := mat.NewDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		var sum float64
+		for j := 0; j < n; j++ {
+			sum += locality.At(i, j)
+		}
+		for j := 0; j < n; j++ {
+			standardized.Set(i, j, locality.At(i, j)/sum)
+		}
+	}
+
+	gotI, gotV, gotZ := MoransIAuto(clusteredGrid, locality)
+	wantI, wantV, wantZ := MoransIExact(clusteredGrid, standardized)
+	if !floats.EqualWithinAbsOrRel(gotI, wantI, 1e-12, 1e-12) {
+		t.Errorf("unexpected Moran's I: got:%v want:%v", gotI, wantI)
+	}
+	if !floats.EqualWithinAbsOrRel(gotV, wantV, 1e-12, 1e-12) {
+		t.Errorf("unexpected variance: got:%v want:%v", gotV, wantV)
+	}
+	if !floats.EqualWithinAbsOrRel(gotZ, wantZ, 1e-12, 1e-12) {
+		t.Errorf("unexpected z-score: got:%v want:%v", gotZ, wantZ)
+	}
+	if locality.At(0, 1) != 1 {
+		t.Error("locality modified")
+	}
+}
+
+func TestMoransIExactPValue(t *testing.T) {
+	// The 3×3 grid is small enough that the normal approximation
+	// is poor.
+	locality := gridLocality(3, 3)
+	for _, data := range [][]float64{
+		{
+			0.5, 1.1, -0.3,
+			0.8, -0.4, 0.2,
+			-0.2, 0.3, -1.1,
+		},
+		{
+			0.3, 1.0, 0.9,
+			-0.6, 0.4, 0.2,
+			-1.3, -0.2, 0.1,
+		},
+	} {
+		got := MoransIExactPValue(data, locality)
+
+		// Compare against a large permutation test.
+		const perms = 50000
+		rnd := rand.New(rand.NewSource(1))
+		obs := moransI(data, locality)
+		perm := make([]float64, len(data))
+		var extreme int
+		for p := 0; p < perms; p++ {
+			for k, j := range rnd.Perm(len(data)) {
+				perm[k] = data[j]
+			}
+			if moransI(perm, locality) >= obs {
+				extreme++
+			}
+		}
+		want := float64(extreme+1) / float64(perms+1)
+		if math.Abs(got-want) > 0.01 {
+			t.Errorf("saddlepoint p-value does not agree with permutation test for %v: got:%v want:%v", data, got, want)
+		}
+	}
+}
+
+func TestMoranPermutationP(t *testing.T) {
+	locality := gridLocality(4, 4)
+	const perms = 999
+
+	p := MoranPermutationP(clusteredGrid, locality, perms, rand.NewSource(1))
+	if p >= 0.01 {
+		t.Errorf("clustered data not significant: p=%v", p)
+	}
+	if again := MoranPermutationP(clusteredGrid, locality, perms, rand.NewSource(1)); again != p {
+		t.Errorf("p-value not reproducible: got:%v want:%v", again, p)
+	}
+
+	// The reference distribution from the same source gives
+	// the same p-value and is centered near -1/(n-1).
+	ref := MoranPermutations(make([]float64, perms), clusteredGrid, locality, rand.NewSource(1))
+	obs := moransI(clusteredGrid, locality)
+	var above, below int
+	for _, v := range ref {
+		if v >= obs {
+			above++
+		}
+		if v <= obs {
+			below++
+		}
+	}
+	if below < above {
+		above = below
+	}
+	if want := math.Min(1, 2*float64(above+1)/(perms+1)); p != want {
+		t.Errorf("p-value does not match reference distribution: got:%v want:%v", p, want)
+	}
+	if mean, want := floats.Sum(ref)/perms, -1.0/15; math.Abs(mean-want) > 0.02 {
+		t.Errorf("unexpected reference distribution mean: got:%v want:%v", mean, want)
+	}
+
+	// The test is two-sided, so the strong negative autocorrelation
+	// of checkerboard data is also significant.
+	checkerboard := []float64{
+		9, 1, 8, 2,
+		2, 7, 1, 8,
+		8, 2, 9, 1,
+		1, 8, 2, 7,
+	}
+	if i := moransI(checkerboard, locality); i >= -1.0/15 {
+		t.Errorf("checkerboard data not negatively autocorrelated: I=%v", i)
+	}
+	if p := MoranPermutationP(checkerboard, locality, perms, rand.NewSource(1)); p >= 0.01 {
+		t.Errorf("checkerboard data not significant: p=%v", p)
+	}
+}
+
+func TestMoransIContributions(t *testing.T) {
+	locality := gridLocality(4, 4)
+	locality.Set(0, 1, 2)
+	c := MoransIContributions(clusteredGrid, locality)
+
+	mean := floats.Sum(clusteredGrid) / float64(len(clusteredGrid))
+	var ss float64
+	for _, v := range clusteredGrid {
+		ss += (v - mean) * (v - mean)
+	}
+	if got, want := floats.Sum(c)/ss, moransI(clusteredGrid, locality); math.Abs(got-want) > 1e-14 {
+		t.Errorf("contributions do not reproduce Moran's I: got:%v want:%v", got, want)
+	}
+
+	// The high-valued corner contributes more than the
+	// units at the boundary between high and low values.
+	for _, i := range []int{0, 1, 4} {
+		for _, j := range []int{2, 6, 8, 9} {
+			if c[i] <= c[j] {
+				t.Errorf("unexpected contribution order for units %d and %d: %v <= %v", i, j, c[i], c[j])
+			}
+		}
+	}
+}
+
+func TestEmpiricalBayesMoran(t *testing.T) {
+	locality := gridLocality(3, 3)
+	events := []float64{
+		12, 10, 3,
+		11, 8, 2,
+		4, 3, 1,
+	}
+
+	// With equal populations the standardization is an affine
+	// transformation of the rates, which leaves Moran's I unchanged.
+	equal := []float64{100, 100, 100, 100, 100, 100, 100, 100, 100}
+	rates := make([]float64, len(events))
+	for k, e := range events {
+		rates[k] = e / equal[k]
+	}
+	i, _, _ := EmpiricalBayesMoran(events, equal, locality)
+	if want := moransI(rates, locality); math.Abs(i-want) > 1e-14 {
+		t.Errorf("unexpected Moran's I for equal populations: got:%v want:%v", i, want)
+	}
+
+	// Check the standardized rates against those computed by hand.
+	population := []float64{
+		250, 80, 40,
+		300, 60, 90,
+		50, 30, 100,
+	}
+	got := ebStandardizedRates(events, population)
+	const b = 54.0 / 1000
+	var s2 float64
+	for k, e := range events {
+		d := e/population[k] - b
+		s2 += population[k] * d * d
+	}
+	s2 /= 1000
+	a := s2 - b/(1000.0/9)
+	for k, e := range events {
+		v := a + b/population[k]
+		if v < 0 {
+			v = b / population[k]
+		}
+		want := (e/population[k] - b) / math.Sqrt(v)
+		if math.Abs(got[k]-want) > 1e-14 {
+			t.Errorf("unexpected standardized rate for unit %d: got:%v want:%v", k, got[k], want)
+		}
+	}
+
+	i, v, z := EmpiricalBayesMoran(events, population, locality)
+	wantI, wantV, wantZ := MoransINormal(got, locality)
+	if i != wantI || v != wantV || z != wantZ {
+		t.Errorf("unexpected result: got:(%v, %v, %v) want:(%v, %v, %v)", i, v, z, wantI, wantV, wantZ)
+	}
+	if i <= 0 {
+		t.Errorf("clustered rates not positively autocorrelated: I=%v", i)
+	}
+}
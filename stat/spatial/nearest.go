@@ -0,0 +1,185 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// NearestNeighbors is a k-d tree over a set of points for answering
+// neighborhood queries without a scan over all pairs of points.
+type NearestNeighbors struct {
+	points [][]float64
+	root   *kdNode
+}
+
+// kdNode is a node of a k-d tree holding the index of its point and the
+// dimension the node splits on.
+type kdNode struct {
+	point       int
+	dim         int
+	left, right *kdNode
+}
+
+// NewNearestNeighbors returns a NearestNeighbors for the points held in the
+// rows of coords. The coordinates are copied.
+func NewNearestNeighbors(coords mat.Matrix) *NearestNeighbors {
+	n, c := coords.Dims()
+	nn := &NearestNeighbors{points: make([][]float64, n)}
+	idx := make([]int, n)
+	for i := range nn.points {
+		nn.points[i] = make([]float64, c)
+		for j := range nn.points[i] {
+			nn.points[i][j] = coords.At(i, j)
+		}
+		idx[i] = i
+	}
+	if c != 0 {
+		nn.root = nn.build(idx, 0)
+	}
+	return nn
+}
+
+// build returns the k-d tree over the points in idx, splitting first on
+// dimension dim.
+func (nn *NearestNeighbors) build(idx []int, dim int) *kdNode {
+	if len(idx) == 0 {
+		return nil
+	}
+	sort.Sort(byCoord{idx: idx, points: nn.points, dim: dim})
+	m := len(idx) / 2
+	next := (dim + 1) % len(nn.points[0])
+	return &kdNode{
+		point: idx[m],
+		dim:   dim,
+		left:  nn.build(idx[:m], next),
+		right: nn.build(idx[m+1:], next),
+	}
+}
+
+// Within returns the indices of the points other than point i within the
+// given Euclidean distance of point i, in ascending order.
+func (nn *NearestNeighbors) Within(i int, radius float64) []int {
+	q := nn.points[i]
+	var found []int
+	var search func(n *kdNode)
+	search = func(n *kdNode) {
+		if n == nil {
+			return
+		}
+		if n.point != i && nn.dist(n.point, q) <= radius {
+			found = append(found, n.point)
+		}
+		d := q[n.dim] - nn.points[n.point][n.dim]
+		if d <= radius {
+			search(n.left)
+		}
+		if -d <= radius {
+			search(n.right)
+		}
+	}
+	search(nn.root)
+	sort.Ints(found)
+	return found
+}
+
+// KNearest returns the indices of the k points nearest to point i, not
+// including point i, in order of increasing Euclidean distance. Points at
+// equal distance are ordered by index. KNearest will panic if k is not in
+// [0, n) where n is the number of points.
+func (nn *NearestNeighbors) KNearest(i, k int) []int {
+	if k < 0 || len(nn.points) <= k {
+		panic("spatial: invalid neighbor count")
+	}
+	if k == 0 {
+		return nil
+	}
+	q := nn.points[i]
+	h := make(neighborHeap, 0, k)
+	var search func(n *kdNode)
+	search = func(n *kdNode) {
+		if n == nil {
+			return
+		}
+		if n.point != i {
+			c := neighbor{point: n.point, dist: nn.dist(n.point, q)}
+			if len(h) < k {
+				heap.Push(&h, c)
+			} else if c.before(h[0]) {
+				h[0] = c
+				heap.Fix(&h, 0)
+			}
+		}
+		d := q[n.dim] - nn.points[n.point][n.dim]
+		near, far := n.left, n.right
+		if d > 0 {
+			near, far = far, near
+		}
+		search(near)
+		if len(h) < k || math.Abs(d) <= h[0].dist {
+			search(far)
+		}
+	}
+	search(nn.root)
+
+	found := make([]int, len(h))
+	for j := len(h) - 1; j >= 0; j-- {
+		found[j] = heap.Pop(&h).(neighbor).point
+	}
+	return found
+}
+
+// dist returns the Euclidean distance between point i and q.
+func (nn *NearestNeighbors) dist(i int, q []float64) float64 {
+	var ss float64
+	for k, v := range nn.points[i] {
+		d := v - q[k]
+		ss += d * d
+	}
+	return math.Sqrt(ss)
+}
+
+// byCoord sorts point indices by a coordinate of the points.
+type byCoord struct {
+	idx    []int
+	points [][]float64
+	dim    int
+}
+
+func (b byCoord) Len() int { return len(b.idx) }
+func (b byCoord) Less(i, j int) bool {
+	return b.points[b.idx[i]][b.dim] < b.points[b.idx[j]][b.dim]
+}
+func (b byCoord) Swap(i, j int) { b.idx[i], b.idx[j] = b.idx[j], b.idx[i] }
+
+// neighbor is a candidate nearest neighbor.
+type neighbor struct {
+	point int
+	dist  float64
+}
+
+// before returns whether n is nearer than m, breaking ties by index.
+func (n neighbor) before(m neighbor) bool {
+	return n.dist < m.dist || (n.dist == m.dist && n.point < m.point)
+}
+
+// neighborHeap is a max-heap of candidate neighbors with the furthest
+// candidate at the root.
+type neighborHeap []neighbor
+
+func (h neighborHeap) Len() int            { return len(h) }
+func (h neighborHeap) Less(i, j int) bool  { return h[j].before(h[i]) }
+func (h neighborHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *neighborHeap) Push(x interface{}) { *h = append(*h, x.(neighbor)) }
+func (h *neighborHeap) Pop() interface{} {
+	old := *h
+	n := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return n
+}
@@ -0,0 +1,96 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// randomPoints returns n random points in the unit square with some
+// duplicated coordinates to exercise ties.
+func randomPoints(n int, rnd *rand.Rand) *mat.Dense {
+	coords := mat.NewDense(n, 2, nil)
+	for i := 0; i < n; i++ {
+		coords.Set(i, 0, float64(rnd.Intn(20))/20)
+		coords.Set(i, 1, rnd.Float64())
+	}
+	return coords
+}
+
+// bruteKNearest returns the k nearest points to point i by a scan over all
+// points, ordered by distance and then index.
+func bruteKNearest(dist mat.Matrix, i, k int) []int {
+	n, _ := dist.Dims()
+	var idx []int
+	for j := 0; j < n; j++ {
+		if j != i {
+			idx = append(idx, j)
+		}
+	}
+	cands := make(neighborHeap, len(idx))
+	for c, j := range idx {
+		cands[c] = neighbor{point: j, dist: dist.At(i, j)}
+	}
+	sort.Sort(sort.Reverse(cands))
+	for c := range idx {
+		idx[c] = cands[c].point
+	}
+	return idx[:k]
+}
+
+func TestNearestNeighbors(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	coords := randomPoints(200, rnd)
+	dist := distances(coords)
+	nn := NewNearestNeighbors(coords)
+
+	for i := 0; i < 200; i += 7 {
+		for _, k := range []int{0, 1, 5, 30, 199} {
+			got := nn.KNearest(i, k)
+			want := bruteKNearest(dist, i, k)
+			if k == 0 {
+				want = nil
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("unexpected %d nearest neighbors of %d:\ngot: %v\nwant:%v", k, i, got, want)
+			}
+		}
+		for _, r := range []float64{0, 0.05, 0.2, 2} {
+			got := nn.Within(i, r)
+			var want []int
+			for j := 0; j < 200; j++ {
+				if j != i && dist.At(i, j) <= r {
+					want = append(want, j)
+				}
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("unexpected neighbors of %d within %v:\ngot: %v\nwant:%v", i, r, got, want)
+			}
+		}
+	}
+}
+
+func BenchmarkNearestNeighborsKNearest(b *testing.B) {
+	coords := randomPoints(2000, rand.New(rand.NewSource(1)))
+	nn := NewNearestNeighbors(coords)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		nn.KNearest(i%2000, 8)
+	}
+}
+
+func BenchmarkBruteKNearest(b *testing.B) {
+	coords := randomPoints(2000, rand.New(rand.NewSource(1)))
+	dist := distances(coords)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bruteKNearest(dist, i%2000, 8)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import "math/rand"
+
+// ConditionalPermute returns the conditional permutation pseudo p-value of a
+// local statistic at the focal unit. The observed statistic is
+// stat(values[focal], v) where v holds the values of the given neighbors in
+// order. For each of reps replicates, values[focal] is held fixed and the
+// neighbor values are replaced by a random sample without replacement from
+// the values of all the other units. If src is nil, the global rand source
+// is used.
+//
+// The p-value is one-sided in the direction of the nearer tail of the
+// permutation distribution: if c replicates give a statistic at least as
+// large as the observed statistic, the returned value is
+//  (min(c, reps-c) + 1) / (reps + 1).
+// The stat function must not retain the neighbor values slice.
+//
+// ConditionalPermute will panic if reps is not positive, if focal is not a
+// valid index into values or if the focal unit is among the neighbors.
+func ConditionalPermute(focal int, values []float64, neighbors []int, stat func(focalValue float64, neighborValues []float64) float64, reps int, src rand.Source) float64 {
+	if reps < 1 {
+		panic("spatial: invalid permutation count")
+	}
+	if focal < 0 || len(values) <= focal {
+		panic("spatial: focal unit out of range")
+	}
+	perm := rand.Perm
+	if src != nil {
+		perm = rand.New(src).Perm
+	}
+
+	nv := make([]float64, len(neighbors))
+	for k, j := range neighbors {
+		if j == focal {
+			panic("spatial: focal unit in neighbors")
+		}
+		nv[k] = values[j]
+	}
+	fv := values[focal]
+	obs := stat(fv, nv)
+
+	others := make([]float64, 0, len(values)-1)
+	for j, v := range values {
+		if j != focal {
+			others = append(others, v)
+		}
+	}
+	var larger int
+	for r := 0; r < reps; r++ {
+		for k, idx := range perm(len(others))[:len(nv)] {
+			nv[k] = others[idx]
+		}
+		if stat(fv, nv) >= obs {
+			larger++
+		}
+	}
+	if reps-larger < larger {
+		larger = reps - larger
+	}
+	return float64(larger+1) / float64(reps+1)
+}
@@ -0,0 +1,39 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+func TestConditionalPermute(t *testing.T) {
+	values := []float64{10, 9, 8, 1, 2, 3, 0, 1, 2, 0}
+	neighbors := []int{1, 2}
+	sum := func(_ float64, v []float64) float64 { return floats.Sum(v) }
+	product := func(f float64, v []float64) float64 { return f * floats.Sum(v) }
+
+	const reps = 999
+	p := ConditionalPermute(0, values, neighbors, sum, reps, rand.NewSource(1))
+	if again := ConditionalPermute(0, values, neighbors, sum, reps, rand.NewSource(1)); p != again {
+		t.Errorf("p-value not stable for fixed seed: %v != %v", p, again)
+	}
+
+	// The neighbors hold the two largest of the other values, so
+	// only the replicates drawing both of them are as extreme. There
+	// are 36 equally likely pairs, so the p-value is close to 1/36.
+	if p > 0.05 {
+		t.Errorf("unexpected p-value for extreme neighbors: got:%v want:<0.05", p)
+	}
+
+	// Scaling by the fixed positive focal value does not change the
+	// ordering of the replicates, so the same random draws must give
+	// the same p-value.
+	if got := ConditionalPermute(0, values, neighbors, product, reps, rand.NewSource(1)); got != p {
+		t.Errorf("unexpected p-value for scaled statistic: got:%v want:%v", got, p)
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+// SpatialScan performs Kulldorff's spatial scan for a cluster of elevated
+// rate under the Poisson model. The events and population at each unit are
+// given by events and population, and the locations of the units by the rows
+// of coords. Circular windows are centered on each unit and grown to include
+// units in order of increasing distance, up to maxRadius and to windows
+// holding at most half of the total population. For a window holding c of
+// the C events with expected count E = C p_z / P under a uniform rate, the
+// log likelihood ratio is
+//  c log(c/E) + (C-c) log((C-c)/(C-E))
+// when c > E and zero otherwise.
+//
+// The window with the largest log likelihood ratio is returned as the index
+// of its center unit and its radius, the distance to its furthest unit. Its
+// significance is assessed by sims Monte Carlo replicates distributing the
+// total number of events, rounded to the nearest integer, over the units in
+// proportion to population. If src is nil, the global rand source is used.
+// If no window has an elevated rate, center is -1 and llr is zero.
+//
+// SpatialScan will panic if the lengths of events and population do not
+// match the number of units, if a population is not positive, or if sims is
+// not positive.
+func SpatialScan(events, population []float64, coords mat.Matrix, maxRadius float64, sims int, src rand.Source) (center int, radius, llr, pValue float64) {
+	n, _ := coords.Dims()
+	if len(events) != n || len(population) != n {
+		panic("spatial: data length mismatch")
+	}
+	for _, p := range population {
+		if p <= 0 {
+			panic("spatial: non-positive population")
+		}
+	}
+	if sims < 1 {
+		panic("spatial: invalid simulation count")
+	}
+	f64 := rand.Float64
+	if src != nil {
+		f64 = rand.New(src).Float64
+	}
+
+	s := newScanner(population, coords, maxRadius)
+	center, radius, llr = s.best(events)
+	if center < 0 {
+		return -1, 0, 0, 1
+	}
+
+	// Simulate the null distribution of the maximum log
+	// likelihood ratio.
+	cdf := make([]float64, n)
+	floats.CumSum(cdf, population)
+	floats.Scale(1/cdf[n-1], cdf)
+	total := int(math.Floor(floats.Sum(events) + 0.5))
+	sim := make([]float64, n)
+	larger := 1
+	for k := 0; k < sims; k++ {
+		for i := range sim {
+			sim[i] = 0
+		}
+		for e := 0; e < total; e++ {
+			i := sort.SearchFloat64s(cdf, f64())
+			if i == n {
+				i = n - 1
+			}
+			sim[i]++
+		}
+		if _, _, l := s.best(sim); l >= llr {
+			larger++
+		}
+	}
+	return center, radius, llr, float64(larger) / float64(sims+1)
+}
+
+// scanner holds the candidate windows of a spatial scan.
+type scanner struct {
+	population []float64
+	totalPop   float64
+
+	// window[i] holds the units in order of their
+	// distance from unit i, and dist the distances.
+	// ends[i] holds the lengths of the prefixes of
+	// window[i] forming distinct windows.
+	window [][]int
+	dist   [][]float64
+	ends   [][]int
+}
+
+// newScanner returns a scanner for the given units.
+func newScanner(population []float64, coords mat.Matrix, maxRadius float64) *scanner {
+	n := len(population)
+	d := distances(coords)
+	s := &scanner{
+		population: population,
+		totalPop:   floats.Sum(population),
+		window:     make([][]int, n),
+		dist:       make([][]float64, n),
+		ends:       make([][]int, n),
+	}
+	for i := 0; i < n; i++ {
+		order := make([]int, n)
+		di := make([]float64, n)
+		for j := range order {
+			order[j] = j
+			di[j] = d.At(i, j)
+		}
+		sort.Sort(byDistance{order: order, dist: di})
+		sorted := make([]float64, n)
+		for k, j := range order {
+			sorted[k] = di[j]
+		}
+
+		var pop float64
+		var ends []int
+		for k := 0; k < n && sorted[k] <= maxRadius; k++ {
+			pop += population[order[k]]
+			if pop > s.totalPop/2 {
+				break
+			}
+			// Units at the same distance enter the
+			// window together.
+			if k+1 < n && sorted[k+1] == sorted[k] {
+				continue
+			}
+			ends = append(ends, k+1)
+		}
+		s.window[i] = order
+		s.dist[i] = sorted
+		s.ends[i] = ends
+	}
+	return s
+}
+
+// best returns the center, radius and log likelihood ratio of the window
+// with the largest log likelihood ratio for the given events.
+func (s *scanner) best(events []float64) (center int, radius, llr float64) {
+	total := floats.Sum(events)
+	center = -1
+	for i, order := range s.window {
+		var c, pop float64
+		k := 0
+		for _, end := range s.ends[i] {
+			for ; k < end; k++ {
+				c += events[order[k]]
+				pop += s.population[order[k]]
+			}
+			e := total * pop / s.totalPop
+			if c <= e {
+				continue
+			}
+			l := c * math.Log(c/e)
+			if total > c {
+				l += (total - c) * math.Log((total-c)/(total-e))
+			}
+			if l > llr {
+				center, radius, llr = i, s.dist[i][end-1], l
+			}
+		}
+	}
+	return center, radius, llr
+}
+
+// byDistance sorts unit indices by their distance.
+type byDistance struct {
+	order []int
+	dist  []float64
+}
+
+func (b byDistance) Len() int           { return len(b.order) }
+func (b byDistance) Less(i, j int) bool { return b.dist[b.order[i]] < b.dist[b.order[j]] }
+func (b byDistance) Swap(i, j int)      { b.order[i], b.order[j] = b.order[j], b.order[i] }
@@ -0,0 +1,52 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestSpatialScan(t *testing.T) {
+	// A 10×10 grid of units with equal population and a planted
+	// high-rate cluster of the units within distance one of (6, 3).
+	const side = 10
+	n := side * side
+	coords := mat.NewDense(n, 2, nil)
+	population := make([]float64, n)
+	events := make([]float64, n)
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < n; i++ {
+		x, y := float64(i%side), float64(i/side)
+		coords.Set(i, 0, x)
+		coords.Set(i, 1, y)
+		population[i] = 1000
+		events[i] = float64(4 + rnd.Intn(3))
+		if math.Hypot(x-6, y-3) <= 1 {
+			events[i] += 12
+		}
+	}
+
+	center, radius, llr, p := SpatialScan(events, population, coords, 3, 99, rand.NewSource(1))
+	if center < 0 {
+		t.Fatal("no cluster detected")
+	}
+	cx, cy := coords.At(center, 0), coords.At(center, 1)
+	for i := 0; i < n; i++ {
+		x, y := coords.At(i, 0), coords.At(i, 1)
+		if math.Hypot(x-6, y-3) <= 1 && math.Hypot(x-cx, y-cy) > radius {
+			t.Errorf("planted cluster unit (%v, %v) not in detected window centered at (%v, %v) with radius %v", x, y, cx, cy, radius)
+		}
+	}
+	if radius > 2 {
+		t.Errorf("detected window too large: radius=%v", radius)
+	}
+	if llr <= 0 || p > 0.05 {
+		t.Errorf("planted cluster not significant: llr=%v p=%v", llr, p)
+	}
+}
@@ -0,0 +1,6 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package spatial provides spatial statistical functions.
+package spatial // import "gonum.org/v1/gonum/stat/spatial"
@@ -0,0 +1,65 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Semivariogram returns the empirical semivariogram of values at the points
+// held in the rows of coords for the distance bands defined by bins. The bins
+// are the increasing upper bounds of the bands, as for
+// SpatialCrossCorrelation, so band k holds the pairs of distinct points with
+// distance in (bins[k-1], bins[k]], with the lower bound of the first band
+// being zero. For each band, lag is the mean distance of its pairs, counts is
+// the number of pairs and gamma is the semivariance
+//  γ_k = \sum_{(i,j) in band k} (x_i - x_j)^2 / (2 N_k)
+// where each unordered pair is counted once and N_k is the number of pairs.
+// Bands holding no pairs have NaN lag and gamma. Pairs further apart than the
+// last bin are ignored.
+//
+// Semivariogram will panic if the length of values does not match the number
+// of points or if bins is not strictly increasing and positive.
+func Semivariogram(values []float64, coords mat.Matrix, bins []float64) (lag, gamma []float64, counts []int) {
+	n, _ := coords.Dims()
+	if len(values) != n {
+		panic("spatial: data length mismatch")
+	}
+	for k, b := range bins {
+		if b <= 0 || (k > 0 && b <= bins[k-1]) {
+			panic("spatial: invalid bins")
+		}
+	}
+
+	dist := distances(coords)
+	lag = make([]float64, len(bins))
+	gamma = make([]float64, len(bins))
+	counts = make([]int, len(bins))
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			d := dist.At(i, j)
+			k := band(d, bins)
+			if k < 0 {
+				continue
+			}
+			diff := values[i] - values[j]
+			lag[k] += d
+			gamma[k] += diff * diff
+			counts[k]++
+		}
+	}
+	for k, c := range counts {
+		if c == 0 {
+			lag[k] = math.NaN()
+			gamma[k] = math.NaN()
+			continue
+		}
+		lag[k] /= float64(c)
+		gamma[k] /= 2 * float64(c)
+	}
+	return lag, gamma, counts
+}
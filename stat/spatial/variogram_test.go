@@ -0,0 +1,50 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestSemivariogram(t *testing.T) {
+	// Points on a transect at unit spacing.
+	values := []float64{1, 3, 2, 5, 4}
+	coords := mat.NewDense(5, 1, []float64{0, 1, 2, 3, 4})
+
+	lag, gamma, counts := Semivariogram(values, coords, []float64{1, 2, 2.5})
+	if want := []int{4, 3, 0}; !reflect.DeepEqual(counts, want) {
+		t.Errorf("unexpected counts: got:%v want:%v", counts, want)
+	}
+	if lag[0] != 1 || lag[1] != 2 || !math.IsNaN(lag[2]) {
+		t.Errorf("unexpected lags: got:%v want:[1 2 NaN]", lag)
+	}
+	// The unit-separated pairs have squared differences 4, 1, 9 and 1,
+	// and the pairs two apart have squared differences 1, 4 and 4.
+	if gamma[0] != 15.0/8 || gamma[1] != 9.0/6 || !math.IsNaN(gamma[2]) {
+		t.Errorf("unexpected semivariances: got:%v want:[1.875 1.5 NaN]", gamma)
+	}
+
+	// A linear trend in the plane has semivariance
+	// increasing with the lag.
+	coords = mat.NewDense(25, 2, nil)
+	values = make([]float64, 25)
+	for i := 0; i < 5; i++ {
+		for j := 0; j < 5; j++ {
+			coords.Set(i*5+j, 0, float64(i))
+			coords.Set(i*5+j, 1, float64(j))
+			values[i*5+j] = float64(i)
+		}
+	}
+	_, gamma, _ = Semivariogram(values, coords, []float64{1, 2, 3})
+	for k := 1; k < len(gamma); k++ {
+		if gamma[k] <= gamma[k-1] {
+			t.Errorf("semivariance not increasing with lag: %v", gamma)
+		}
+	}
+}
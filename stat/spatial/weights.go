@@ -0,0 +1,404 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/topo"
+	"gonum.org/v1/gonum/mat"
+)
+
+// AdaptiveKernel returns a locality matrix for the points held in the rows of
+// coords using an adaptive bandwidth kernel. The bandwidth of each point i is
+// its distance to its k-th nearest neighbor, b_i, and the weight between
+// points i and j is
+//  w_ij = kernel(d_ij / b_i)
+// where d_ij is the Euclidean distance between the points. The diagonal of
+// the returned matrix is zero. Points in dense regions have narrower
+// neighborhoods than points in sparse regions. The returned matrix is not
+// in general symmetric.
+//
+// AdaptiveKernel will panic if k is not in [1, n), where n is the number of
+// points. If the bandwidth of a point is zero, its row is zero.
+func AdaptiveKernel(coords mat.Matrix, k int, kernel func(dNormalized float64) float64) *mat.Dense {
+	n, _ := coords.Dims()
+	if k < 1 || n <= k {
+		panic("spatial: invalid neighbor count")
+	}
+
+	nn := NewNearestNeighbors(coords)
+	w := mat.NewDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		b := nn.dist(i, nn.points[nn.KNearest(i, k)[k-1]])
+		if b == 0 {
+			continue
+		}
+		for j := 0; j < n; j++ {
+			if j == i {
+				continue
+			}
+			w.Set(i, j, kernel(nn.dist(i, nn.points[j])/b))
+		}
+	}
+	return w
+}
+
+// KNNWeights returns a binary locality matrix for the points held in the rows
+// of coords where w_ij is 1 if j is among the k nearest neighbors of i by
+// Euclidean distance and 0 otherwise. Ties at the k-th distance are broken in
+// favor of the lower index. The diagonal of the returned matrix is zero, and
+// the matrix is not in general symmetric.
+//
+// KNNWeights will panic if k is not in [1, n), where n is the number of
+// points.
+func KNNWeights(coords mat.Matrix, k int) *mat.Dense {
+	n, _ := coords.Dims()
+	if k < 1 || n <= k {
+		panic("spatial: invalid neighbor count")
+	}
+	nn := NewNearestNeighbors(coords)
+	w := mat.NewDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		for _, j := range nn.KNearest(i, k) {
+			w.Set(i, j, 1)
+		}
+	}
+	return w
+}
+
+// DistanceBandWeights returns a locality matrix for the points held in the
+// rows of coords connecting each pair of distinct points within the threshold
+// Euclidean distance of each other. If binary is true the weight of each
+// connected pair is 1, otherwise it is the inverse distance, 1/d_ij. All other
+// weights, including the diagonal, are zero, and the returned matrix is
+// symmetric.
+//
+// Points with no other point within the threshold have all-zero rows. Such
+// rows are left unchanged by row standardization, so an isolated point has a
+// spatial lag of zero rather than being excluded; RequireNeighbors may be
+// used to suppress statistics for these points.
+//
+// DistanceBandWeights will panic if threshold is negative, or if binary is
+// false and two connected points coincide.
+func DistanceBandWeights(coords mat.Matrix, threshold float64, binary bool) *mat.Dense {
+	if threshold < 0 {
+		panic("spatial: negative threshold")
+	}
+	n, _ := coords.Dims()
+	nn := NewNearestNeighbors(coords)
+	w := mat.NewDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		for _, j := range nn.Within(i, threshold) {
+			if binary {
+				w.Set(i, j, 1)
+				continue
+			}
+			d := nn.dist(i, nn.points[j])
+			if d == 0 {
+				panic("spatial: coincident points")
+			}
+			w.Set(i, j, 1/d)
+		}
+	}
+	return w
+}
+
+// RookContiguity returns the binary rook contiguity locality for a grid with
+// the given numbers of rows and columns. Cells are indexed in row-major
+// order, so cell (r, c) is unit r*cols+c, and each cell is joined to the
+// cells sharing an edge with it. Interior cells have four neighbors, edge
+// cells three and corner cells two. The diagonal of the returned matrix is
+// zero. RookContiguity will panic if rows or cols is not positive.
+func RookContiguity(rows, cols int) *mat.Dense {
+	return gridContiguity(rows, cols, false)
+}
+
+// QueenContiguity returns the binary queen contiguity locality for a grid
+// with the given numbers of rows and columns. Cells are indexed as for
+// RookContiguity, and each cell is joined to the cells sharing an edge or a
+// corner with it. Interior cells have eight neighbors, edge cells five and
+// corner cells three. The diagonal of the returned matrix is zero.
+// QueenContiguity will panic if rows or cols is not positive.
+func QueenContiguity(rows, cols int) *mat.Dense {
+	return gridContiguity(rows, cols, true)
+}
+
+// gridContiguity returns the rook or queen contiguity locality for a grid.
+func gridContiguity(rows, cols int, queen bool) *mat.Dense {
+	if rows < 1 || cols < 1 {
+		panic("spatial: invalid grid dimensions")
+	}
+	n := rows * cols
+	w := mat.NewDense(n, n, nil)
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			for dr := -1; dr <= 1; dr++ {
+				for dc := -1; dc <= 1; dc++ {
+					if dr == 0 && dc == 0 || !queen && dr != 0 && dc != 0 {
+						continue
+					}
+					nr, nc := r+dr, c+dc
+					if nr < 0 || rows <= nr || nc < 0 || cols <= nc {
+						continue
+					}
+					w.Set(r*cols+c, nr*cols+nc, 1)
+				}
+			}
+		}
+	}
+	return w
+}
+
+// CombineLocalities returns the linear combination of the localities,
+//  \sum_k coeffs[k] mats[k],
+// allowing neighbor definitions such as contiguity and distance to be
+// blended. Diagonal elements are combined in the same way as all other
+// elements. CombineLocalities will panic if mats is empty, if the lengths
+// of mats and coeffs differ or if the localities do not all have the same
+// dimensions.
+func CombineLocalities(mats []mat.Matrix, coeffs []float64) *mat.Dense {
+	if len(mats) == 0 {
+		panic("spatial: no localities")
+	}
+	if len(mats) != len(coeffs) {
+		panic("spatial: coefficient length mismatch")
+	}
+	r, c := mats[0].Dims()
+	combined := mat.NewDense(r, c, nil)
+	var scaled mat.Dense
+	for k, m := range mats {
+		if mr, mc := m.Dims(); mr != r || mc != c {
+			panic("spatial: locality dimension mismatch")
+		}
+		scaled.Scale(coeffs[k], m)
+		combined.Add(combined, &scaled)
+	}
+	return combined
+}
+
+// EffectiveNeighbors returns the effective number of neighbors of each unit
+// of the locality,
+//  1 / \sum_j v_ij^2,
+// where v is the row-standardized locality. For a unit with k neighbors of
+// equal weight the effective number of neighbors is k, and uneven weights
+// reduce it below the number of non-zero weights. Units with no neighbors
+// have an effective number of neighbors of zero. The locality must be
+// square, otherwise EffectiveNeighbors will panic.
+func EffectiveNeighbors(locality mat.Matrix) []float64 {
+	r, c := locality.Dims()
+	if r != c {
+		panic("spatial: locality not square")
+	}
+	v := rowStandardized(locality)
+	eff := make([]float64, r)
+	for i := range eff {
+		row := v.RawRowView(i)
+		if ss := floats.Dot(row, row); ss != 0 {
+			eff[i] = 1 / ss
+		}
+	}
+	return eff
+}
+
+// WeightsEigenvalues returns the eigenvalues of the symmetrized locality,
+// (W + Wᵀ)/2, sorted in descending order. The symmetrized locality is
+// symmetric, so its eigenvalues are real. For a locality that is already
+// symmetric the eigenvalues are those of the locality itself. The locality
+// must be square, otherwise WeightsEigenvalues will panic.
+//
+// The eigenvalues are used by the exact moment and saddlepoint methods for
+// Moran's I, and may be computed once and reused when many analyses share
+// the same locality.
+func WeightsEigenvalues(locality mat.Matrix) []float64 {
+	r, c := locality.Dims()
+	if r != c {
+		panic("spatial: locality not square")
+	}
+	var e mat.EigenSym
+	if !e.Factorize(symmetrized(locality), false) {
+		panic("spatial: eigendecomposition failed")
+	}
+	values := e.Values(nil)
+	sort.Sort(sort.Reverse(sort.Float64Slice(values)))
+	return values
+}
+
+// ConnectedRegions returns the connected components of the graph of the
+// locality, where units i and j are joined when either w_ij or w_ji is
+// non-zero. Each component holds the indices of its units in ascending
+// order, and the components are ordered by their lowest index. A locality
+// describing a single connected study region has one component; islands
+// and disconnected subregions appear as additional components. The
+// locality must be square, otherwise ConnectedRegions will panic.
+func ConnectedRegions(locality mat.Matrix) [][]int {
+	r, c := locality.Dims()
+	if r != c {
+		panic("spatial: locality not square")
+	}
+	g := simple.NewUndirectedGraph(0, 0)
+	for i := 0; i < r; i++ {
+		g.AddNode(simple.Node(i))
+	}
+	for i := 0; i < r; i++ {
+		for j := i + 1; j < r; j++ {
+			if locality.At(i, j) != 0 || locality.At(j, i) != 0 {
+				g.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node(j)})
+			}
+		}
+	}
+
+	cc := topo.ConnectedComponents(g)
+	regions := make([][]int, len(cc))
+	for k, nodes := range cc {
+		ids := make([]int, len(nodes))
+		for m, n := range nodes {
+			ids[m] = n.ID()
+		}
+		sort.Ints(ids)
+		regions[k] = ids
+	}
+	sort.Sort(byLowest(regions))
+	return regions
+}
+
+// byLowest sorts non-empty ascending index slices by their first element.
+type byLowest [][]int
+
+func (r byLowest) Len() int           { return len(r) }
+func (r byLowest) Less(i, j int) bool { return r[i][0] < r[j][0] }
+func (r byLowest) Swap(i, j int)      { r[i], r[j] = r[j], r[i] }
+
+// LocalityFromGraph returns the weighted adjacency of g as a locality matrix
+// and the node IDs indexing its rows and columns, which are sorted in
+// ascending order. Data to be analyzed with the locality must be ordered
+// in the same way. The weights are obtained as described for
+// LocalityFromGraphNodes.
+func LocalityFromGraph(g graph.Graph) (*mat.Dense, []int) {
+	nodes := g.Nodes()
+	sort.Sort(byNodeID(nodes))
+	ids := make([]int, len(nodes))
+	for i, n := range nodes {
+		ids[i] = n.ID()
+	}
+	return LocalityFromGraphNodes(g, nodes), ids
+}
+
+// byNodeID sorts nodes by their ID.
+type byNodeID []graph.Node
+
+func (n byNodeID) Len() int           { return len(n) }
+func (n byNodeID) Less(i, j int) bool { return n[i].ID() < n[j].ID() }
+func (n byNodeID) Swap(i, j int)      { n[i], n[j] = n[j], n[i] }
+
+// LocalityFromGraphNodes returns the weighted adjacency of g between the given
+// nodes as a locality matrix, where w_ij is the weight of the edge from
+// nodes[i] to nodes[j]. For a directed graph the locality is in general
+// asymmetric, and for an undirected graph it is symmetric. The weights are
+// those of simple.AdjacencyMatrix: if g is a graph.Weighter they are obtained
+// from its Weight method, otherwise from the edges' Weight method, and pairs
+// of nodes without an edge and edges carrying the absent weight reported by
+// the graph's Absent method, including a NaN absent weight, have zero weight.
+// The diagonal, where the graph's self weight would apply, is zero. Edges to
+// nodes not in the given nodes are ignored, and nodes not in g have all-zero
+// rows and columns.
+//
+// LocalityFromGraphNodes will panic if nodes holds duplicate node IDs.
+func LocalityFromGraphNodes(g graph.Graph, nodes []graph.Node) *mat.Dense {
+	if len(nodes) == 0 {
+		return &mat.Dense{}
+	}
+	locality := simple.AdjacencyMatrix(g, nodes)
+	for i := range nodes {
+		locality.Set(i, i, 0)
+	}
+	return locality
+}
+
+// Symmetrize returns the symmetrized locality, (W + Wᵀ)/2. Asymmetric
+// localities, such as those returned by KNNWeights, become symmetric with
+// the weight of each pair of units averaged over the two directions. The
+// locality must be square, otherwise Symmetrize will panic.
+func Symmetrize(locality mat.Matrix) *mat.Dense {
+	r, c := locality.Dims()
+	if r != c {
+		panic("spatial: locality not square")
+	}
+	var s mat.Dense
+	s.Clone(symmetrized(locality))
+	return &s
+}
+
+// ZeroDiagonal returns a copy of the locality with its diagonal set to zero,
+// removing the self weights w_ii. The functions of this package include any
+// self weights of a locality in their weight sums and cross-products; for
+// Moran's I they contribute w_ii z_i^2 to the numerator and w_ii to S_0, so
+// zeroing the diagonal gives
+//  I' = n/(S_0 - tr(W)) (\sum_ij w_ij z_i z_j - \sum_i w_ii z_i^2) / \sum_i z_i^2,
+// where z holds the deviations of the data from their mean. The locality is
+// not modified and must be square, otherwise ZeroDiagonal will panic.
+func ZeroDiagonal(locality mat.Matrix) *mat.Dense {
+	r, c := locality.Dims()
+	if r != c {
+		panic("spatial: locality not square")
+	}
+	var w mat.Dense
+	w.Clone(locality)
+	for i := 0; i < r; i++ {
+		w.Set(i, i, 0)
+	}
+	return &w
+}
+
+// IsSymmetric returns whether the locality is square and each pair of
+// weights w_ij and w_ji differ by no more than tol.
+func IsSymmetric(locality mat.Matrix, tol float64) bool {
+	r, c := locality.Dims()
+	if r != c {
+		return false
+	}
+	for i := 0; i < r; i++ {
+		for j := i + 1; j < r; j++ {
+			if math.Abs(locality.At(i, j)-locality.At(j, i)) > tol {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// symmetrized returns (W + Wᵀ)/2 for the square matrix w.
+func symmetrized(w mat.Matrix) *mat.SymDense {
+	n, _ := w.Dims()
+	s := mat.NewSymDense(n, nil)
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			s.SetSym(i, j, (w.At(i, j)+w.At(j, i))/2)
+		}
+	}
+	return s
+}
+
+// distances returns the symmetric matrix of Euclidean distances between the
+// points held in the rows of coords.
+func distances(coords mat.Matrix) *mat.SymDense {
+	n, c := coords.Dims()
+	d := mat.NewSymDense(n, nil)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			var ss float64
+			for k := 0; k < c; k++ {
+				v := coords.At(i, k) - coords.At(j, k)
+				ss += v * v
+			}
+			d.SetSym(i, j, math.Sqrt(ss))
+		}
+	}
+	return d
+}
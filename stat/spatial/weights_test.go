@@ -0,0 +1,431 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/mat"
+)
+
+// bisquare is the bisquare kernel function.
+func bisquare(u float64) float64 {
+	if u >= 1 {
+		return 0
+	}
+	u = 1 - u*u
+	return u * u
+}
+
+func TestAdaptiveKernel(t *testing.T) {
+	// Points 0-3 are densely packed and points 4-7 are sparse.
+	coords := mat.NewDense(8, 1, []float64{
+		0, 0.1, 0.2, 0.3,
+		10, 20, 30, 40,
+	})
+	const k = 3
+	w := AdaptiveKernel(coords, k, bisquare)
+
+	// reach returns the furthest distance from i with a non-zero weight.
+	reach := func(i int) float64 {
+		var max float64
+		for j := 0; j < 8; j++ {
+			if w.At(i, j) == 0 {
+				continue
+			}
+			d := coords.At(j, 0) - coords.At(i, 0)
+			if d < 0 {
+				d = -d
+			}
+			if d > max {
+				max = d
+			}
+		}
+		return max
+	}
+
+	for i := 0; i < 8; i++ {
+		if w.At(i, i) != 0 {
+			t.Errorf("unexpected non-zero diagonal at %d: %v", i, w.At(i, i))
+		}
+	}
+	for _, dense := range []int{0, 1, 2, 3} {
+		for _, sparse := range []int{4, 5, 6, 7} {
+			if reach(dense) >= reach(sparse) {
+				t.Errorf("dense unit %d neighborhood not narrower than sparse unit %d: %v >= %v",
+					dense, sparse, reach(dense), reach(sparse))
+			}
+		}
+	}
+
+	// The k-th nearest neighbor lies on the kernel boundary.
+	if got := w.At(0, 3); got != 0 {
+		t.Errorf("unexpected weight at bandwidth: got:%v want:0", got)
+	}
+	if got, want := w.At(0, 1), bisquare(1.0/3); got != want {
+		t.Errorf("unexpected weight inside bandwidth: got:%v want:%v", got, want)
+	}
+}
+
+func TestWeightsEigenvalues(t *testing.T) {
+	locality := gridLocality(3, 4)
+	got := WeightsEigenvalues(locality)
+
+	n, _ := locality.Dims()
+	sym := mat.NewSymDense(n, nil)
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			sym.SetSym(i, j, locality.At(i, j))
+		}
+	}
+	var e mat.EigenSym
+	if !e.Factorize(sym, false) {
+		t.Fatal("unexpected factorization failure")
+	}
+	want := e.Values(nil)
+	sort.Sort(sort.Reverse(sort.Float64Slice(want)))
+	if !floats.EqualApprox(got, want, 1e-12) {
+		t.Errorf("unexpected eigenvalues: got:%v want:%v", got, want)
+	}
+	if !sort.IsSorted(sort.Reverse(sort.Float64Slice(got))) {
+		t.Errorf("eigenvalues not in descending order: %v", got)
+	}
+	if sum, trace := floats.Sum(got), mat.Trace(locality); math.Abs(sum-trace) > 1e-12 {
+		t.Errorf("eigenvalues do not sum to trace: got:%v want:%v", sum, trace)
+	}
+
+	// The trace is also preserved for a row-standardized locality
+	// with non-zero diagonal.
+	w := mat.NewDense(3, 3, []float64{
+		0.5, 0.5, 0,
+		0.25, 0.25, 0.5,
+		0, 1, 0,
+	})
+	if sum, trace := floats.Sum(WeightsEigenvalues(w)), mat.Trace(w); math.Abs(sum-trace) > 1e-12 {
+		t.Errorf("eigenvalues do not sum to trace: got:%v want:%v", sum, trace)
+	}
+}
+
+func TestConnectedRegions(t *testing.T) {
+	// Two blocks, {0, 2, 4} and {1, 3}, with an asymmetric
+	// link and an isolated unit 5.
+	w := mat.NewDense(6, 6, []float64{
+		0, 0, 1, 0, 0, 0,
+		0, 0, 0, 1, 0, 0,
+		1, 0, 0, 0, 0, 0,
+		0, 0.5, 0, 0, 0, 0,
+		0, 0, 2, 0, 0, 0,
+		0, 0, 0, 0, 0, 1,
+	})
+	got := ConnectedRegions(w)
+	want := [][]int{{0, 2, 4}, {1, 3}, {5}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected regions: got:%v want:%v", got, want)
+	}
+
+	if got := ConnectedRegions(gridLocality(3, 4)); len(got) != 1 {
+		t.Errorf("unexpected number of regions for connected grid: got:%d want:1", len(got))
+	}
+}
+
+func TestCombineLocalities(t *testing.T) {
+	coords := mat.NewDense(3, 2, []float64{
+		0, 0,
+		3, 4,
+		0, 2,
+	})
+	contiguity := mat.NewDense(3, 3, []float64{
+		0, 0, 1,
+		0, 0, 1,
+		1, 1, 0,
+	})
+	inverse := mat.NewDense(3, 3, nil)
+	dist := distances(coords)
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			if i != j {
+				inverse.Set(i, j, 1/dist.At(i, j))
+			}
+		}
+	}
+	inverse.Set(1, 1, 2)
+
+	got := CombineLocalities([]mat.Matrix{contiguity, inverse}, []float64{0.5, 2})
+	want := mat.NewDense(3, 3, []float64{
+		0, 2.0 / 5, 0.5 + 2.0/2,
+		2.0 / 5, 4, 0.5 + 2/math.Sqrt(13),
+		0.5 + 2.0/2, 0.5 + 2/math.Sqrt(13), 0,
+	})
+	if !mat.EqualApprox(got, want, 1e-14) {
+		t.Errorf("unexpected combined locality:\ngot: %v\nwant:%v", mat.Formatted(got), mat.Formatted(want))
+	}
+}
+
+func TestEffectiveNeighbors(t *testing.T) {
+	w := mat.NewDense(4, 4, []float64{
+		0, 1, 1, 1,
+		1, 0, 0, 0,
+		3, 1, 0, 0,
+		0, 0, 0, 0,
+	})
+	got := EffectiveNeighbors(w)
+	want := []float64{3, 1, 1 / (0.75*0.75 + 0.25*0.25), 0}
+	if !floats.EqualApprox(got, want, 1e-14) {
+		t.Errorf("unexpected effective neighbors: got:%v want:%v", got, want)
+	}
+	if got[2] >= 2 {
+		t.Errorf("uneven weights did not reduce effective neighbors below raw count: %v", got[2])
+	}
+
+	// Interior units of a rook grid have four equal neighbors.
+	if got := EffectiveNeighbors(gridLocality(3, 3))[4]; math.Abs(got-4) > 1e-14 {
+		t.Errorf("unexpected effective neighbors for interior unit: got:%v want:4", got)
+	}
+}
+
+func TestLocalityFromGraph(t *testing.T) {
+	// A graph with an absent sentinel of -1 and an edge
+	// carrying that weight, which must be given zero weight.
+	g := simple.NewUndirectedGraph(0, -1)
+	for _, e := range []simple.Edge{
+		{F: simple.Node(10), T: simple.Node(20), W: 2},
+		{F: simple.Node(20), T: simple.Node(30), W: 1},
+		{F: simple.Node(30), T: simple.Node(40), W: 0.5},
+		{F: simple.Node(40), T: simple.Node(10), W: 1},
+		{F: simple.Node(10), T: simple.Node(30), W: -1},
+	} {
+		g.SetEdge(e)
+	}
+	got, ids := LocalityFromGraph(g)
+	if want := []int{10, 20, 30, 40}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("unexpected ids: got:%v want:%v", ids, want)
+	}
+	want := mat.NewDense(4, 4, []float64{
+		0, 2, 0, 1,
+		2, 0, 1, 0,
+		0, 1, 0, 0.5,
+		1, 0, 0.5, 0,
+	})
+	if !mat.Equal(got, want) {
+		t.Errorf("unexpected locality:\ngot: %v\nwant:%v", mat.Formatted(got), mat.Formatted(want))
+	}
+
+	// A NaN absent sentinel must also match edges carrying it,
+	// even though NaN is not equal to itself.
+	nan := simple.NewDirectedGraph(0, math.NaN())
+	for _, e := range []simple.Edge{
+		{F: simple.Node(0), T: simple.Node(1), W: 3},
+		{F: simple.Node(1), T: simple.Node(2), W: math.NaN()},
+		{F: simple.Node(2), T: simple.Node(0), W: 0.5},
+	} {
+		nan.SetEdge(e)
+	}
+	got, ids = LocalityFromGraph(nan)
+	if want := []int{0, 1, 2}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("unexpected ids: got:%v want:%v", ids, want)
+	}
+	want = mat.NewDense(3, 3, []float64{
+		0, 3, 0,
+		0, 0, 0,
+		0.5, 0, 0,
+	})
+	if !mat.Equal(got, want) {
+		t.Errorf("unexpected locality for NaN absent weight:\ngot: %v\nwant:%v", mat.Formatted(got), mat.Formatted(want))
+	}
+}
+
+func TestLocalityFromGraphNodes(t *testing.T) {
+	g := simple.NewDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.Edge{
+		{F: simple.Node(1), T: simple.Node(2), W: 2},
+		{F: simple.Node(2), T: simple.Node(1), W: 0.5},
+		{F: simple.Node(2), T: simple.Node(3), W: 1},
+		{F: simple.Node(3), T: simple.Node(4), W: 3},
+	} {
+		g.SetEdge(e)
+	}
+
+	// Node 4 is not requested, so the edge to it is dropped,
+	// and node 9 is not in the graph.
+	nodes := []graph.Node{simple.Node(3), simple.Node(1), simple.Node(2), simple.Node(9)}
+	got := LocalityFromGraphNodes(g, nodes)
+	want := mat.NewDense(4, 4, []float64{
+		0, 0, 0, 0,
+		0, 0, 2, 0,
+		1, 0.5, 0, 0,
+		0, 0, 0, 0,
+	})
+	if !mat.Equal(got, want) {
+		t.Errorf("unexpected locality:\ngot: %v\nwant:%v", mat.Formatted(got), mat.Formatted(want))
+	}
+
+	all, ids := LocalityFromGraph(g)
+	if want := []int{1, 2, 3, 4}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("unexpected ids: got:%v want:%v", ids, want)
+	}
+	if all.At(2, 3) != 3 || all.At(3, 2) != 0 {
+		t.Errorf("unexpected directed weights: got:%v and %v want:3 and 0", all.At(2, 3), all.At(3, 2))
+	}
+}
+
+func TestKNNWeights(t *testing.T) {
+	// Points 1 and 3 are equidistant from point 0,
+	// and the tie is broken by the lower index.
+	coords := mat.NewDense(5, 2, []float64{
+		0, 0,
+		1, 0,
+		5, 5,
+		0, 1,
+		5, 6,
+	})
+	got := KNNWeights(coords, 1)
+	want := mat.NewDense(5, 5, []float64{
+		0, 1, 0, 0, 0,
+		1, 0, 0, 0, 0,
+		0, 0, 0, 0, 1,
+		1, 0, 0, 0, 0,
+		0, 0, 1, 0, 0,
+	})
+	if !mat.Equal(got, want) {
+		t.Errorf("unexpected weights:\ngot: %v\nwant:%v", mat.Formatted(got), mat.Formatted(want))
+	}
+
+	got = KNNWeights(coords, 2)
+	for i := 0; i < 5; i++ {
+		if s := floats.Sum(got.RawRowView(i)); s != 2 {
+			t.Errorf("unexpected neighbor count for point %d: got:%v want:2", i, s)
+		}
+	}
+	if got.At(0, 1) != 1 || got.At(0, 3) != 1 {
+		t.Errorf("unexpected neighbors for point 0: %v", got.RawRowView(0))
+	}
+}
+
+func TestDistanceBandWeights(t *testing.T) {
+	// Point 3 is isolated.
+	coords := mat.NewDense(4, 2, []float64{
+		0, 0,
+		3, 4,
+		0, 2,
+		10, 10,
+	})
+	got := DistanceBandWeights(coords, 5, true)
+	want := mat.NewDense(4, 4, []float64{
+		0, 1, 1, 0,
+		1, 0, 1, 0,
+		1, 1, 0, 0,
+		0, 0, 0, 0,
+	})
+	if !mat.Equal(got, want) {
+		t.Errorf("unexpected binary weights:\ngot: %v\nwant:%v", mat.Formatted(got), mat.Formatted(want))
+	}
+
+	got = DistanceBandWeights(coords, 5, false)
+	want = mat.NewDense(4, 4, []float64{
+		0, 1.0 / 5, 1.0 / 2, 0,
+		1.0 / 5, 0, 1 / math.Sqrt(13), 0,
+		1.0 / 2, 1 / math.Sqrt(13), 0, 0,
+		0, 0, 0, 0,
+	})
+	if !mat.EqualApprox(got, want, 1e-14) {
+		t.Errorf("unexpected inverse distance weights:\ngot: %v\nwant:%v", mat.Formatted(got), mat.Formatted(want))
+	}
+
+	// The isolated point remains all-zero after row standardization.
+	if row := rowStandardized(got).RawRowView(3); floats.Sum(row) != 0 {
+		t.Errorf("unexpected non-zero row for isolated point: %v", row)
+	}
+}
+
+func TestGridContiguity(t *testing.T) {
+	const rows, cols = 3, 4
+	for _, test := range []struct {
+		name                   string
+		w                      *mat.Dense
+		interior, edge, corner float64
+	}{
+		{name: "rook", w: RookContiguity(rows, cols), interior: 4, edge: 3, corner: 2},
+		{name: "queen", w: QueenContiguity(rows, cols), interior: 8, edge: 5, corner: 3},
+	} {
+		if !mat.Equal(test.w, test.w.T()) {
+			t.Errorf("%s contiguity not symmetric", test.name)
+		}
+		for _, c := range []struct {
+			unit int
+			want float64
+		}{
+			{unit: 0, want: test.corner},
+			{unit: 3, want: test.corner},
+			{unit: 8, want: test.corner},
+			{unit: 11, want: test.corner},
+			{unit: 1, want: test.edge},
+			{unit: 4, want: test.edge},
+			{unit: 7, want: test.edge},
+			{unit: 10, want: test.edge},
+			{unit: 5, want: test.interior},
+			{unit: 6, want: test.interior},
+		} {
+			if got := floats.Sum(test.w.RawRowView(c.unit)); got != c.want {
+				t.Errorf("unexpected %s neighbor count for unit %d: got:%v want:%v", test.name, c.unit, got, c.want)
+			}
+		}
+	}
+
+	if !mat.Equal(RookContiguity(rows, cols), gridLocality(rows, cols)) {
+		t.Error("rook contiguity does not match grid locality")
+	}
+	q := QueenContiguity(rows, cols)
+	if q.At(0, 5) != 1 || q.At(5, 0) != 1 || q.At(0, 2) != 0 {
+		t.Errorf("unexpected queen diagonal neighbors: %v", q.RawRowView(0))
+	}
+}
+
+func TestSymmetrize(t *testing.T) {
+	sym := gridLocality(2, 3)
+	if !IsSymmetric(sym, 0) {
+		t.Error("symmetric locality reported as asymmetric")
+	}
+	if got := Symmetrize(sym); !mat.Equal(got, sym) {
+		t.Errorf("unexpected symmetrization of symmetric locality:\ngot: %v\nwant:%v", mat.Formatted(got), mat.Formatted(sym))
+	}
+
+	w := mat.NewDense(3, 3, []float64{
+		0, 1, 0,
+		0, 2, 1,
+		1, 1e-10, 0,
+	})
+	if IsSymmetric(w, 0) {
+		t.Error("asymmetric locality reported as symmetric")
+	}
+	got := Symmetrize(w)
+	want := mat.NewDense(3, 3, []float64{
+		0, 0.5, 0.5,
+		0.5, 2, 0.5 + 0.5e-10,
+		0.5, 0.5 + 0.5e-10, 0,
+	})
+	if !mat.EqualApprox(got, want, 1e-15) {
+		t.Errorf("unexpected symmetrization:\ngot: %v\nwant:%v", mat.Formatted(got), mat.Formatted(want))
+	}
+	if !IsSymmetric(got, 0) {
+		t.Error("symmetrized locality reported as asymmetric")
+	}
+
+	// The tolerance allows small differences.
+	w.Set(0, 1, 1e-10)
+	w.Set(0, 2, 1)
+	w.Set(1, 2, 0)
+	if !IsSymmetric(w, 1e-9) {
+		t.Error("nearly symmetric locality reported as asymmetric")
+	}
+	if IsSymmetric(mat.NewDense(2, 3, nil), 1) {
+		t.Error("non-square matrix reported as symmetric")
+	}
+}